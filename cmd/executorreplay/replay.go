@@ -0,0 +1,245 @@
+// Command executorreplay re-submits requests recorded by the executor server
+// audit log (-audit-log) to a server and reports the verdict differences
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/criyle/go-judge/cmd/executorserver/model"
+	"github.com/criyle/go-judge/envexec"
+)
+
+var (
+	srvAddr       = flag.String("srvaddr", "http://localhost:5050", "REST server addr")
+	compareOutput = flag.Bool("output", false, "also compare the collected outputs recorded in the audit log")
+)
+
+type replayResult struct {
+	Status     model.Status      `json:"status"`
+	ExitStatus int               `json:"exitStatus"`
+	Files      map[string]string `json:"files"`
+}
+
+type replayer struct {
+	client *http.Client
+	token  string
+
+	total, same, different, skipped, failed int
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [audit.log ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	r := &replayer{
+		client: http.DefaultClient,
+		token:  os.Getenv("TOKEN"),
+	}
+	files := flag.Args()
+	if len(files) == 0 {
+		r.replay("stdin", os.Stdin)
+	}
+	for _, p := range files {
+		f, err := os.Open(p)
+		if err != nil {
+			log.Fatalln("open", err)
+		}
+		r.replay(p, f)
+		f.Close()
+	}
+
+	fmt.Printf("total %d, same %d, different %d, skipped %d, failed %d\n",
+		r.total, r.same, r.different, r.skipped, r.failed)
+	if r.different > 0 || r.failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func (r *replayer) replay(name string, in io.Reader) {
+	d := json.NewDecoder(in)
+	for {
+		var rec model.AuditRecord
+		if err := d.Decode(&rec); err == io.EOF {
+			return
+		} else if err != nil {
+			log.Fatalf("%s: decode: %v", name, err)
+		}
+		r.total++
+		id := rec.Request.RequestID
+		if id == "" {
+			id = fmt.Sprintf("%s#%d", name, r.total)
+		}
+
+		if reason := notReplayable(&rec.Request); reason != "" {
+			r.skipped++
+			fmt.Printf("%s: skipped: %s\n", id, reason)
+			continue
+		}
+		results, err := r.run(&rec.Request)
+		if err != nil {
+			if rec.Response.ErrorMsg != "" {
+				r.same++
+				continue
+			}
+			r.failed++
+			fmt.Printf("%s: failed: %v\n", id, err)
+			continue
+		}
+		if diff := compareResults(rec.Response.Results, results); len(diff) > 0 {
+			r.different++
+			fmt.Printf("%s: %s\n", id, strings.Join(diff, "; "))
+			continue
+		}
+		r.same++
+	}
+}
+
+func (r *replayer) run(req *model.Request) ([]replayResult, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	hr, err := http.NewRequest(http.MethodPost, *srvAddr+"/run", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	hr.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		hr.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.client.Do(hr)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	var results []replayResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// compareResults returns the differences of the verdicts (and the outputs)
+func compareResults(recorded []model.AuditResult, replayed []replayResult) []string {
+	if len(recorded) != len(replayed) {
+		return []string{fmt.Sprintf("recorded %d results, replayed %d results", len(recorded), len(replayed))}
+	}
+	var diff []string
+	for i, rec := range recorded {
+		rep := replayed[i]
+		if rec.Status != rep.Status || rec.ExitStatus != rep.ExitStatus {
+			diff = append(diff, fmt.Sprintf("result %d: recorded %v (%d), replayed %v (%d)",
+				i, statusString(rec.Status), rec.ExitStatus, statusString(rep.Status), rep.ExitStatus))
+			continue
+		}
+		if !*compareOutput {
+			continue
+		}
+		for name, content := range rep.Files {
+			if c, ok := rec.Files[name]; ok && c != content {
+				diff = append(diff, fmt.Sprintf("result %d: output %s differs", i, name))
+			}
+			if h, ok := rec.FileHashes[name]; ok && h != contentHash(content) {
+				diff = append(diff, fmt.Sprintf("result %d: output %s differs", i, name))
+			}
+		}
+	}
+	return diff
+}
+
+// notReplayable returns the reason if the request cannot be replayed
+func notReplayable(req *model.Request) string {
+	var reason string
+	var checkFile func(f *model.CmdFile)
+	checkFile = func(f *model.CmdFile) {
+		switch {
+		case f == nil || reason != "":
+		case f.Archive != nil:
+			checkFile(f.Archive.File)
+		case f.ContentHash != nil:
+			reason = "content is hashed (record with -audit-log-inline)"
+		case f.Src == nil && f.Content == nil && f.FileID == nil && f.StageFile == nil && f.Max == nil:
+			reason = "stream file is not replayable"
+		}
+	}
+	var checkCmd func(c *model.Cmd)
+	checkCmd = func(c *model.Cmd) {
+		if c.TTY {
+			reason = "tty is not replayable"
+		}
+		for _, f := range c.Files {
+			checkFile(f)
+		}
+		for _, f := range c.CopyIn {
+			f := f
+			checkFile(&f)
+		}
+		if c.Compare != nil {
+			checkFile(c.Compare.Expected)
+		}
+		if c.Checker != nil {
+			checkCmd(&c.Checker.Cmd)
+			checkFile(c.Checker.Input)
+			checkFile(c.Checker.Answer)
+		}
+	}
+	checkBatch := func(b *model.Batch) {
+		if b == nil {
+			return
+		}
+		checkCmd(&b.Cmd)
+		for _, bc := range b.Cases {
+			checkFile(bc.Stdin)
+			checkFile(bc.Expected)
+			checkFile(bc.Answer)
+		}
+	}
+	checkInteractive := func(it *model.Interactive) {
+		if it == nil {
+			return
+		}
+		checkCmd(&it.Solution)
+		checkCmd(&it.Interactor)
+	}
+
+	for i := range req.Cmd {
+		checkCmd(&req.Cmd[i])
+	}
+	checkBatch(req.Batch)
+	checkInteractive(req.Interactive)
+	for _, s := range req.Stages {
+		for i := range s.Cmd {
+			checkCmd(&s.Cmd[i])
+		}
+		checkBatch(s.Batch)
+		checkInteractive(s.Interactive)
+	}
+	return reason
+}
+
+func statusString(s model.Status) string {
+	return envexec.Status(s).String()
+}
+
+func contentHash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return model.ContentHashPrefix + hex.EncodeToString(h[:])
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/criyle/go-judge/cmd/executorserver/model"
+	"github.com/criyle/go-judge/envexec"
+)
+
+func TestNotReplayable(t *testing.T) {
+	s := func(v string) *string { return &v }
+	max := int64(1024)
+	content := &model.CmdFile{Content: s("in")}
+	hashed := &model.CmdFile{ContentHash: s("sha256:00")}
+	stream := &model.CmdFile{}
+	collector := &model.CmdFile{Name: s("stdout"), Max: &max}
+
+	tests := []struct {
+		name   string
+		req    model.Request
+		reason string
+	}{
+		{"replayable", model.Request{Cmd: []model.Cmd{{Files: []*model.CmdFile{content, collector, nil}}}}, ""},
+		{"tty", model.Request{Cmd: []model.Cmd{{TTY: true}}}, "tty"},
+		{"hashed file", model.Request{Cmd: []model.Cmd{{Files: []*model.CmdFile{hashed}}}}, "hashed"},
+		{"hashed copy in", model.Request{Cmd: []model.Cmd{{CopyIn: map[string]model.CmdFile{"a": *hashed}}}}, "hashed"},
+		{"hashed archive", model.Request{Cmd: []model.Cmd{{CopyIn: map[string]model.CmdFile{
+			"a": {Archive: &model.ArchiveFile{File: hashed}},
+		}}}}, "hashed"},
+		{"stream", model.Request{Cmd: []model.Cmd{{Files: []*model.CmdFile{stream}}}}, "stream"},
+		{"compare", model.Request{Cmd: []model.Cmd{{Compare: &model.Compare{Expected: hashed}}}}, "hashed"},
+		{"checker cmd", model.Request{Cmd: []model.Cmd{{Checker: &model.Checker{Cmd: model.Cmd{TTY: true}}}}}, "tty"},
+		{"checker answer", model.Request{Cmd: []model.Cmd{{Checker: &model.Checker{Answer: hashed}}}}, "hashed"},
+		{"batch case", model.Request{Batch: &model.Batch{Cases: []model.BatchCase{{Stdin: content}, {Expected: hashed}}}}, "hashed"},
+		{"interactive", model.Request{Interactive: &model.Interactive{Interactor: model.Cmd{Files: []*model.CmdFile{stream}}}}, "stream"},
+		{"stage", model.Request{Stages: []model.Stage{{Cmd: []model.Cmd{{}}}, {Batch: &model.Batch{Cmd: model.Cmd{TTY: true}}}}}, "tty"},
+		{"stage file", model.Request{Cmd: []model.Cmd{{Files: []*model.CmdFile{{StageFile: s("exe")}}}}}, ""},
+	}
+	for _, tc := range tests {
+		reason := notReplayable(&tc.req)
+		if tc.reason == "" && reason != "" {
+			t.Errorf("%s: expected replayable, got %q", tc.name, reason)
+		}
+		if tc.reason != "" && !strings.Contains(reason, tc.reason) {
+			t.Errorf("%s: expected reason containing %q, got %q", tc.name, tc.reason, reason)
+		}
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	accepted, wa := model.Status(envexec.StatusAccepted), model.Status(envexec.StatusWrongAnswer)
+	recorded := []model.AuditResult{
+		{Status: accepted, Files: map[string]string{"stdout": "1\n"}},
+		{Status: accepted, FileHashes: map[string]string{"stdout": contentHash("2\n")}},
+	}
+	tests := []struct {
+		name     string
+		output   bool
+		replayed []replayResult
+		diff     []string
+	}{
+		{"same", true, []replayResult{
+			{Status: accepted, Files: map[string]string{"stdout": "1\n"}},
+			{Status: accepted, Files: map[string]string{"stdout": "2\n"}},
+		}, nil},
+		{"count", false, []replayResult{{Status: accepted}}, []string{"recorded 2 results, replayed 1 results"}},
+		{"status", false, []replayResult{
+			{Status: wa},
+			{Status: accepted},
+		}, []string{"result 0: recorded Accepted (0), replayed Wrong Answer (0)"}},
+		{"exit status", false, []replayResult{
+			{Status: accepted},
+			{Status: accepted, ExitStatus: 1},
+		}, []string{"result 1: recorded Accepted (0), replayed Accepted (1)"}},
+		{"output ignored", false, []replayResult{
+			{Status: accepted, Files: map[string]string{"stdout": "x"}},
+			{Status: accepted, Files: map[string]string{"stdout": "y"}},
+		}, nil},
+		{"output", true, []replayResult{
+			{Status: accepted, Files: map[string]string{"stdout": "x", "stderr": "new"}},
+			{Status: accepted, Files: map[string]string{"stdout": "y"}},
+		}, []string{"result 0: output stdout differs", "result 1: output stdout differs"}},
+	}
+	defer func(v bool) { *compareOutput = v }(*compareOutput)
+	for _, tc := range tests {
+		*compareOutput = tc.output
+		diff := compareResults(recorded, tc.replayed)
+		if strings.Join(diff, "; ") != strings.Join(tc.diff, "; ") {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.diff, diff)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/criyle/go-judge/cmd/executorserver/model"
+	"github.com/criyle/go-judge/worker"
+)
+
+// auditLog appends requests and responses as JSON lines into the file and
+// rotates it into path.1 ... path.N once it exceeds the max size
+type auditLog struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	inline     bool
+
+	f    *os.File
+	size int64
+}
+
+func newAuditLog(path string, maxSize int64, maxBackups int, inline bool) (*auditLog, error) {
+	a := &auditLog{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		inline:     inline,
+	}
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *auditLog) open() error {
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	a.f, a.size = f, fi.Size()
+	return nil
+}
+
+// observe records the request with its response, it is used as worker AuditObserver
+func (a *auditLog) observe(req *worker.Request, rt worker.Response) {
+	b, err := json.Marshal(model.ConvertAuditRecord(req, rt, a.inline, a.maxSize))
+	if err != nil {
+		logger.Sugar().Warnf("audit log: failed to encode %s: %v", req.RequestID, err)
+		return
+	}
+	b = append(b, '\n')
+	if err := a.write(b); err != nil {
+		logger.Sugar().Warnf("audit log: failed to write %s: %v", req.RequestID, err)
+	}
+}
+
+func (a *auditLog) write(b []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.f == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+	if a.maxSize > 0 && a.size > 0 && a.size+int64(len(b)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := a.f.Write(b)
+	a.size += int64(n)
+	return err
+}
+
+func (a *auditLog) rotate() error {
+	if err := a.f.Close(); err != nil {
+		return err
+	}
+	a.f = nil
+	if a.maxBackups > 0 {
+		for i := a.maxBackups - 1; i > 0; i-- {
+			os.Rename(a.backup(i), a.backup(i+1))
+		}
+		if err := os.Rename(a.path, a.backup(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(a.path); err != nil {
+		return err
+	}
+	return a.open()
+}
+
+func (a *auditLog) backup(i int) string {
+	return fmt.Sprintf("%s.%d", a.path, i)
+}
+
+func (a *auditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.f == nil {
+		return nil
+	}
+	err := a.f.Close()
+	a.f = nil
+	return err
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogRotate(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackups int
+		files      map[string]string
+	}{
+		{"no backup", 0, map[string]string{"audit.log": "ccc\n"}},
+		{"one backup", 1, map[string]string{"audit.log": "ccc\n", "audit.log.1": "bbb\n"}},
+		{"two backups", 2, map[string]string{"audit.log": "ccc\n", "audit.log.1": "bbb\n", "audit.log.2": "aaa\n"}},
+		{"more backups", 3, map[string]string{"audit.log": "ccc\n", "audit.log.1": "bbb\n", "audit.log.2": "aaa\n"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			a, err := newAuditLog(filepath.Join(dir, "audit.log"), 6, tc.maxBackups, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// each line fits the max size but not two of them
+			for _, l := range []string{"aaa\n", "bbb\n", "ccc\n"} {
+				if err := a.write([]byte(l)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := a.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := a.write([]byte("ddd\n")); err == nil {
+				t.Error("write after close succeeded")
+			}
+			checkAuditFiles(t, dir, tc.files)
+		})
+	}
+}
+
+func TestAuditLogSize(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "audit.log")
+	if err := os.WriteFile(p, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the existing content counts toward the max size
+	a, err := newAuditLog(p, 8, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	for _, l := range []string{"abc\n", "d\n", "efghijklmn\n", "o\n"} {
+		if err := a.write([]byte(l)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a line larger than the max size is written into an empty file as a whole
+	checkAuditFiles(t, dir, map[string]string{
+		"audit.log":   "o\n",
+		"audit.log.1": "efghijklmn\n",
+	})
+}
+
+func TestAuditLogUnlimited(t *testing.T) {
+	dir := t.TempDir()
+	a, err := newAuditLog(filepath.Join(dir, "audit.log"), 0, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	for i := 0; i < 10; i++ {
+		if err := a.write([]byte("line\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkAuditFiles(t, dir, map[string]string{"audit.log": strings.Repeat("line\n", 10)})
+}
+
+func checkAuditFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(files) {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("expected %d files, got %v", len(files), names)
+	}
+	for name, content := range files {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if string(b) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, b)
+		}
+	}
+}
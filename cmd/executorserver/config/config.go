@@ -31,11 +31,32 @@ type Config struct {
 	ExtraMemoryLimit         *envexec.Size `flagUsage:"specifies extra memory buffer for check memory limit" default:"16k"`
 	OutputLimit              *envexec.Size `flagUsage:"specifies POSIX rlimit for output for each command" default:"256m"`
 	CopyOutLimit             *envexec.Size `flagUsage:"specifies default file copy out max" default:"64m"`
+	CopyOutDirLimit          *envexec.Size `flagUsage:"specifies default total size of files dumped by copy out dir" default:"256m"`
 	OpenFileLimit            int           `flagUsage:"specifies max open file count" default:"256"`
+	ArchiveLimit             *envexec.Size `flagUsage:"specifies default size limit of files extracted from copy in archive" default:"256m"`
+	ArchiveInodeLimit        int           `flagUsage:"specifies default max number of files and directories extracted from copy in archive" default:"4096"`
 	Cpuset                   string        `flagUsage:"control the usage of cpuset for all containerd process"`
 	EnableCPURate            bool          `flagUsage:"enable cpu cgroup rate control"`
 	CPUCfsPeriod             time.Duration `flagUsage:"set cpu.cfs_period" default:"100ms"`
 	FileTimeout              time.Duration `flagUsage:"specified timeout for filestore files"`
+	PriorityAging            time.Duration `flagUsage:"specifies interval to raise priority by one for waiting requests" default:"1s"`
+	QueueSize                int           `flagUsage:"specifies max number of waiting requests" default:"512"`
+	QueueBlocking            bool          `flagUsage:"wait for queue capacity until request deadline instead of rejecting immediately"`
+	ExecParallelism          int           `flagUsage:"control the # of concurrency streaming execution, separately from parallelism (0 for unlimited)"`
+	ExecQueueSize            int           `flagUsage:"specifies max number of streaming executions waiting for concurrency" default:"64"`
+	TenantConf               string        `flagUsage:"specifies tenant configuration file for fair scheduling"`
+	ResultCacheSize          *envexec.Size `flagUsage:"enables result cache for single commands with max size of cached files (0 to disable)" default:"0"`
+	ResultCacheAge           time.Duration `flagUsage:"specifies max age of cached results (0 for unlimited)" default:"10m"`
+	RetryAttempts            int           `flagUsage:"specifies max number of runs for single commands by the retry policy (1 to disable)" default:"1"`
+	RetryTimeMargin          float64       `flagUsage:"re-run accepted and time limit exceeded results with cpu time within the ratio of cpu limit (e.g. 0.05)"`
+	RetryInternalError       bool          `flagUsage:"re-run internal error results"`
+	RetryCombine             string        `flagUsage:"specifies how to choose the result from the attempts (min, median)" default:"min"`
+	AuditLog                 string        `flagUsage:"specifies file to append requests and responses as JSON lines for audit and replay"`
+	AuditLogInline           bool          `flagUsage:"inline file contents into audit log instead of their sha256"`
+	AuditLogMaxSize          *envexec.Size `flagUsage:"specifies max size of audit log before it is rotated" default:"100m"`
+	AuditLogMaxBackups       int           `flagUsage:"specifies max number of rotated audit logs to keep" default:"3"`
+	ShutdownGrace            time.Duration `flagUsage:"specifies grace period for running requests to finish on shutdown" default:"3s"`
+	DrainQueued              bool          `flagUsage:"finish queued requests within shutdown grace period instead of cancelling them"`
 
 	// server config
 	HTTPAddr      string `flagUsage:"specifies the http binding address" default:":5050"`
@@ -2,6 +2,7 @@ package grpcexecutor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -60,7 +61,7 @@ func (e *execServer) Exec(ctx context.Context, req *pb.Request) (*pb.Response, e
 	rt := <-rtCh
 	e.logger.Sugar().Debugf("response: %+v", rt)
 	if rt.Error != nil {
-		return nil, rt.Error
+		return nil, workerError(rt.Error)
 	}
 	ret, err := model.ConvertResponse(rt, false)
 	if err != nil {
@@ -69,6 +70,17 @@ func (e *execServer) Exec(ctx context.Context, req *pb.Request) (*pb.Response, e
 	return convertPBResponse(ret)
 }
 
+// workerError converts the admission errors of the worker into status
+func workerError(err error) error {
+	if errors.Is(err, worker.ErrQueueFull) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, worker.ErrShuttingDown) || errors.Is(err, worker.ErrCancelled) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
 func (e *execServer) FileList(c context.Context, n *emptypb.Empty) (*pb.FileListType, error) {
 	return &pb.FileListType{
 		FileIDs: e.fs.List(),
@@ -120,11 +132,32 @@ func (e *execServer) FileDelete(c context.Context, f *pb.FileID) (*emptypb.Empty
 	return &emptypb.Empty{}, nil
 }
 
+func (e *execServer) GetParallelism(c context.Context, n *emptypb.Empty) (*pb.Parallelism, error) {
+	if !worker.IsAdmin(c) {
+		return nil, status.Error(codes.PermissionDenied, "admin token required")
+	}
+	target, current := e.worker.Parallelism()
+	return &pb.Parallelism{Parallelism: int32(target), Current: int32(current)}, nil
+}
+
+func (e *execServer) SetParallelism(c context.Context, p *pb.Parallelism) (*pb.Parallelism, error) {
+	if !worker.IsAdmin(c) {
+		return nil, status.Error(codes.PermissionDenied, "admin token required")
+	}
+	if p.GetParallelism() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "parallelism must be positive")
+	}
+	e.worker.SetParallelism(int(p.GetParallelism()))
+	e.logger.Sugar().Infof("parallelism set to %d", p.GetParallelism())
+	return e.GetParallelism(c, &emptypb.Empty{})
+}
+
 func convertPBResponse(r model.Response) (*pb.Response, error) {
 	res := &pb.Response{
 		RequestID: r.RequestID,
 		Results:   make([]*pb.Response_Result, 0, len(r.Results)),
 		Error:     r.ErrorMsg,
+		WaitTime:  r.WaitTime,
 	}
 	for _, c := range r.Results {
 		rt, err := convertPBResult(c)
@@ -147,9 +180,63 @@ func convertPBResult(r model.Result) (*pb.Response_Result, error) {
 		Files:      r.Buffs,
 		FileIDs:    r.FileIDs,
 		FileError:  convertPBFileError(r.FileError),
+		Diff:       r.Diff,
+
+		Score:          r.Score,
+		CheckerMessage: r.CheckerMessage,
+		UsageTimeline:  convertPBUsageTimeline(r.UsageTimeline),
+		Attempts:       convertPBAttempts(r.Attempts),
+		CopyOutDir:     convertPBCopyOutDir(r.CopyOutDir),
+		OutputSize:     r.OutputSize,
+
+		GroupLimitExceeded: pb.Response_Result_GroupLimitType(pb.Response_Result_GroupLimitType_value[r.GroupLimitExceeded]),
+		Killed:             r.Killed,
 	}, nil
 }
 
+func convertPBAttempts(a []model.Attempt) []*pb.Response_Attempt {
+	if len(a) == 0 {
+		return nil
+	}
+	rt := make([]*pb.Response_Attempt, 0, len(a))
+	for _, at := range a {
+		rt = append(rt, &pb.Response_Attempt{
+			Status:  pb.Response_Result_StatusType(at.Status),
+			Time:    at.Time,
+			RunTime: at.RunTime,
+			Memory:  at.Memory,
+		})
+	}
+	return rt
+}
+
+func convertPBCopyOutDir(m []model.CopyOutDirEntry) []*pb.Response_CopyOutDirEntry {
+	if len(m) == 0 {
+		return nil
+	}
+	rt := make([]*pb.Response_CopyOutDirEntry, 0, len(m))
+	for _, e := range m {
+		rt = append(rt, &pb.Response_CopyOutDirEntry{
+			Name:    e.Name,
+			Mode:    e.Mode,
+			Size:    e.Size,
+			Skipped: e.Skipped,
+		})
+	}
+	return rt
+}
+
+func convertPBUsageTimeline(t *model.UsageTimeline) *pb.Response_UsageTimeline {
+	if t == nil {
+		return nil
+	}
+	return &pb.Response_UsageTimeline{
+		Elapsed: t.Elapsed,
+		Time:    t.Time,
+		Memory:  t.Memory,
+	}
+}
+
 func convertPBFileError(fe []envexec.FileError) []*pb.Response_FileError {
 	rt := make([]*pb.Response_FileError, 0, len(fe))
 	for _, e := range fe {
@@ -179,6 +266,11 @@ func convertPBRequest(r *pb.Request, srcPrefix string) (req *worker.Request, str
 		RequestID:   r.RequestID,
 		Cmd:         make([]worker.Cmd, 0, len(r.Cmd)),
 		PipeMapping: make([]worker.PipeMap, 0, len(r.PipeMapping)),
+		GroupLimit:  convertPBGroupLimit(r.GetGroupLimit()),
+		GroupPolicy: envexec.GroupPolicy(r.GetGroupPolicy()),
+		KillOnExit:  convertPBKillOnExit(r.GetKillOnExit()),
+		Priority:    int(r.GetPriority()),
+		Tenant:      r.GetTenant(),
 	}
 	for _, c := range r.Cmd {
 		cm, si, so, err := convertPBCmd(c, srcPrefix)
@@ -193,9 +285,146 @@ func convertPBRequest(r *pb.Request, srcPrefix string) (req *worker.Request, str
 		pm := convertPBPipeMap(p)
 		req.PipeMapping = append(req.PipeMapping, pm)
 	}
+	if r.GetBatch() != nil {
+		b, si, so, err := convertPBBatch(r.GetBatch(), srcPrefix)
+		streamIn = append(streamIn, si...)
+		streamOut = append(streamOut, so...)
+		if err != nil {
+			return nil, streamIn, streamOut, err
+		}
+		req.Batch = b
+	}
+	if r.GetInteractive() != nil {
+		it, si, so, err := convertPBInteractive(r.GetInteractive(), srcPrefix)
+		streamIn = append(streamIn, si...)
+		streamOut = append(streamOut, so...)
+		if err != nil {
+			return nil, streamIn, streamOut, err
+		}
+		req.Interactive = it
+	}
+	for _, s := range r.GetStages() {
+		ws := worker.Stage{
+			Cmd:         make([]worker.Cmd, 0, len(s.GetCmd())),
+			PipeMapping: make([]worker.PipeMap, 0, len(s.GetPipeMapping())),
+			GroupLimit:  convertPBGroupLimit(s.GetGroupLimit()),
+			GroupPolicy: envexec.GroupPolicy(s.GetGroupPolicy()),
+			KillOnExit:  convertPBKillOnExit(s.GetKillOnExit()),
+		}
+		for _, c := range s.GetCmd() {
+			cm, si, so, err := convertPBCmd(c, srcPrefix)
+			streamIn = append(streamIn, si...)
+			streamOut = append(streamOut, so...)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+			ws.Cmd = append(ws.Cmd, cm)
+		}
+		for _, p := range s.GetPipeMapping() {
+			ws.PipeMapping = append(ws.PipeMapping, convertPBPipeMap(p))
+		}
+		if s.GetBatch() != nil {
+			b, si, so, err := convertPBBatch(s.GetBatch(), srcPrefix)
+			streamIn = append(streamIn, si...)
+			streamOut = append(streamOut, so...)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+			ws.Batch = b
+		}
+		if s.GetInteractive() != nil {
+			it, si, so, err := convertPBInteractive(s.GetInteractive(), srcPrefix)
+			streamIn = append(streamIn, si...)
+			streamOut = append(streamOut, so...)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+			ws.Interactive = it
+		}
+		req.Stages = append(req.Stages, ws)
+	}
 	return req, streamIn, streamOut, nil
 }
 
+func convertPBInteractive(it *pb.Request_Interactive, srcPrefix string) (*worker.Interactive, []*fileStreamIn, []*fileStreamOut, error) {
+	solution, streamIn, streamOut, err := convertPBCmd(it.GetSolution(), srcPrefix)
+	if err != nil {
+		return nil, streamIn, streamOut, err
+	}
+	interactor, si, so, err := convertPBCmd(it.GetInteractor(), srcPrefix)
+	streamIn = append(streamIn, si...)
+	streamOut = append(streamOut, so...)
+	if err != nil {
+		return nil, streamIn, streamOut, err
+	}
+	return &worker.Interactive{
+		Solution:      solution,
+		Interactor:    interactor,
+		TranscriptMax: envexec.Size(it.GetTranscriptMax()),
+	}, streamIn, streamOut, nil
+}
+
+func convertPBBatch(b *pb.Request_Batch, srcPrefix string) (*worker.Batch, []*fileStreamIn, []*fileStreamOut, error) {
+	cm, streamIn, streamOut, err := convertPBCmd(b.GetCmd(), srcPrefix)
+	if err != nil {
+		return nil, streamIn, streamOut, err
+	}
+	wb := &worker.Batch{
+		Cmd:           cm,
+		Cases:         make([]worker.BatchCase, 0, len(b.GetCases())),
+		StopOnFailure: b.GetStopOnFailure(),
+	}
+	for _, bc := range b.GetCases() {
+		var stdin, expected, answer worker.CmdFile
+		if bc.GetStdin() != nil {
+			stdin, err = convertPBFile(bc.GetStdin(), srcPrefix)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+		}
+		if bc.GetExpected() != nil {
+			expected, err = convertPBFile(bc.GetExpected(), srcPrefix)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+		}
+		if bc.GetAnswer() != nil {
+			answer, err = convertPBCopyInFile(bc.GetAnswer(), srcPrefix)
+			if err != nil {
+				return nil, streamIn, streamOut, err
+			}
+		}
+		wb.Cases = append(wb.Cases, worker.BatchCase{
+			Stdin:       stdin,
+			Expected:    expected,
+			Answer:      answer,
+			CPULimit:    time.Duration(bc.GetCpuTimeLimit()),
+			ClockLimit:  time.Duration(bc.GetClockTimeLimit()),
+			MemoryLimit: envexec.Size(bc.GetMemoryLimit()),
+		})
+	}
+	return wb, streamIn, streamOut, nil
+}
+
+func convertPBGroupLimit(l *pb.Request_GroupLimit) worker.GroupLimit {
+	return worker.GroupLimit{
+		Time:   time.Duration(l.GetCpuTimeLimit()),
+		Memory: envexec.Size(l.GetMemoryLimit()),
+		Proc:   l.GetProcLimit(),
+	}
+}
+
+func convertPBKillOnExit(k []int32) []int {
+	if len(k) == 0 {
+		return nil
+	}
+	rt := make([]int, 0, len(k))
+	for _, i := range k {
+		rt = append(rt, int(i))
+	}
+	return rt
+}
+
 func convertPBPipeMap(p *pb.Request_PipeMap) worker.PipeMap {
 	return worker.PipeMap{
 		In: worker.PipeIndex{
@@ -206,10 +435,23 @@ func convertPBPipeMap(p *pb.Request_PipeMap) worker.PipeMap {
 			Index: int(p.GetOut().GetIndex()),
 			Fd:    int(p.GetOut().GetFd()),
 		},
-		Proxy: p.GetProxy(),
-		Name:  p.GetName(),
-		Limit: worker.Size(p.Max),
+		Proxy:      p.GetProxy(),
+		Name:       p.GetName(),
+		Limit:      worker.Size(p.Max),
+		Transcript: p.GetTranscript(),
+		FanOut:     convertPBPipeIndexes(p.GetFanOut()),
+	}
+}
+
+func convertPBPipeIndexes(pi []*pb.Request_PipeMap_PipeIndex) []worker.PipeIndex {
+	if len(pi) == 0 {
+		return nil
 	}
+	rt := make([]worker.PipeIndex, 0, len(pi))
+	for _, p := range pi {
+		rt = append(rt, worker.PipeIndex{Index: int(p.GetIndex()), Fd: int(p.GetFd())})
+	}
+	return rt
 }
 
 func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, streamIn []*fileStreamIn, streamOut []*fileStreamOut, err error) {
@@ -231,16 +473,27 @@ func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, strea
 		TTY:               c.GetTty(),
 		CPULimit:          time.Duration(c.GetCpuTimeLimit()),
 		ClockLimit:        time.Duration(c.GetClockTimeLimit()),
+		IdleLimit:         time.Duration(c.GetIdleTimeLimit()),
 		MemoryLimit:       envexec.Size(c.GetMemoryLimit()),
 		StackLimit:        envexec.Size(c.GetStackLimit()),
 		ProcLimit:         c.GetProcLimit(),
 		CPURateLimit:      c.GetCpuRateLimit(),
 		CPUSetLimit:       c.GetCpuSetLimit(),
 		StrictMemoryLimit: c.GetStrictMemoryLimit(),
-		CopyOut:           convertCopyOut(c.GetCopyOut()),
-		CopyOutCached:     convertCopyOut(c.GetCopyOutCached()),
 		CopyOutMax:        c.GetCopyOutMax(),
 		CopyOutDir:        c.GetCopyOutDir(),
+		CopyOutDirMax:     c.GetCopyOutDirMax(),
+		NoCache:           c.GetNoCache(),
+		UsageTimeline:     c.GetUsageTimeline(),
+	}
+	if cm.CopyOut, err = convertCopyOut(c.GetCopyOut()); err != nil {
+		return cm, streamIn, streamOut, err
+	}
+	if cm.CopyOutCached, err = convertCopyOut(c.GetCopyOutCached()); err != nil {
+		return cm, streamIn, streamOut, err
+	}
+	if cm.CopyOutStage, err = convertCopyOut(c.GetCopyOutStage()); err != nil {
+		return cm, streamIn, streamOut, err
 	}
 	for _, f := range c.GetFiles() {
 		var cf worker.CmdFile
@@ -266,18 +519,73 @@ func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, strea
 	if copyIn := c.GetCopyIn(); copyIn != nil {
 		cm.CopyIn = make(map[string]worker.CmdFile)
 		for k, f := range copyIn {
-			cf, err := convertPBFile(f, srcPrefix)
+			cf, err := convertPBCopyInFile(f, srcPrefix)
 			if err != nil {
 				return cm, streamIn, streamOut, err
 			}
 			cm.CopyIn[k] = cf
 		}
 	}
+	if cmp := c.GetCompare(); cmp != nil {
+		expected, err := convertPBFile(cmp.GetExpected(), srcPrefix)
+		if err != nil {
+			return cm, streamIn, streamOut, err
+		}
+		cm.Compare = &worker.Compare{
+			Expected:     expected,
+			Mode:         worker.CompareMode(cmp.GetMode()),
+			AbsTolerance: cmp.GetAbsTolerance(),
+			RelTolerance: cmp.GetRelTolerance(),
+		}
+	}
+	if ck := c.GetChecker(); ck != nil {
+		ckCmd, si, so, err := convertPBCmd(ck.GetCmd(), srcPrefix)
+		streamIn = append(streamIn, si...)
+		streamOut = append(streamOut, so...)
+		if err != nil {
+			return cm, streamIn, streamOut, err
+		}
+		input, err := convertPBCopyInFile(ck.GetInput(), srcPrefix)
+		if err != nil {
+			return cm, streamIn, streamOut, err
+		}
+		answer, err := convertPBCopyInFile(ck.GetAnswer(), srcPrefix)
+		if err != nil {
+			return cm, streamIn, streamOut, err
+		}
+		cm.Checker = &worker.Checker{
+			Cmd:    ckCmd,
+			Input:  input,
+			Answer: answer,
+		}
+	}
 	return cm, streamIn, streamOut, nil
 }
 
+// convertPBFile converts the file used other than copyIn (e.g. files), which
+// does not support mode and verifyUnchanged
 func convertPBFile(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error) {
-	switch c := c.File.(type) {
+	if c.GetMode() != 0 || c.GetVerifyUnchanged() {
+		return nil, fmt.Errorf("file: mode and verifyUnchanged are only supported by copyIn")
+	}
+	return convertPBFileSource(c, srcPrefix)
+}
+
+// convertPBCopyInFile converts the file copied into the work dir
+func convertPBCopyInFile(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error) {
+	cf, err := convertPBFileSource(c, srcPrefix)
+	if err != nil || cf == nil || (c.GetMode() == 0 && !c.GetVerifyUnchanged()) {
+		return cf, err
+	}
+	return &worker.CopyInFile{
+		Source:          cf,
+		Mode:            os.FileMode(c.GetMode()) & os.ModePerm,
+		VerifyUnchanged: c.GetVerifyUnchanged(),
+	}, nil
+}
+
+func convertPBFileSource(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error) {
+	switch c := c.GetFile().(type) {
 	case nil:
 		return nil, nil
 	case *pb.Request_File_Local:
@@ -296,7 +604,24 @@ func convertPBFile(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error)
 	case *pb.Request_File_Cached:
 		return &worker.CachedFile{FileID: c.Cached.GetFileID()}, nil
 	case *pb.Request_File_Pipe:
-		return &worker.Collector{Name: c.Pipe.GetName(), Max: envexec.Size(c.Pipe.GetMax()), Pipe: c.Pipe.GetPipe()}, nil
+		return &worker.Collector{Name: c.Pipe.GetName(), Max: envexec.Size(c.Pipe.GetMax()), Pipe: c.Pipe.GetPipe(), Tail: envexec.Size(c.Pipe.GetTail())}, nil
+	case *pb.Request_File_Stage:
+		return &worker.StageFile{Name: c.Stage.GetName()}, nil
+	case *pb.Request_File_Archive:
+		src, err := convertPBFile(c.Archive.GetFile(), srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		format, err := envexec.StringToArchiveFormat(c.Archive.GetFormat())
+		if err != nil {
+			return nil, err
+		}
+		return &worker.ArchiveFile{
+			Source:     src,
+			Format:     format,
+			Limit:      envexec.Size(c.Archive.GetSizeLimit()),
+			InodeLimit: int(c.Archive.GetInodeLimit()),
+		}, nil
 	}
 	return nil, fmt.Errorf("request file type not supported yet %v", c)
 }
@@ -312,13 +637,25 @@ func checkPathPrefix(path, prefix string) (bool, error) {
 	return strings.HasPrefix(filepath.Join(wd, path), prefix), nil
 }
 
-func convertCopyOut(copyOut []*pb.Request_CmdCopyOutFile) []worker.CmdCopyOutFile {
+func convertCopyOut(copyOut []*pb.Request_CmdCopyOutFile) ([]worker.CmdCopyOutFile, error) {
 	rt := make([]worker.CmdCopyOutFile, 0, len(copyOut))
 	for _, n := range copyOut {
-		rt = append(rt, worker.CmdCopyOutFile{
+		f := worker.CmdCopyOutFile{
 			Name:     n.GetName(),
 			Optional: n.GetOptional(),
-		})
+		}
+		if a := n.GetArchive(); a != nil {
+			format, err := envexec.StringToArchiveFormat(a.GetFormat())
+			if err != nil {
+				return nil, err
+			}
+			f.Archive = &envexec.CopyOutArchive{
+				Format:    format,
+				Patterns:  a.GetPatterns(),
+				FileLimit: int(a.GetMaxFiles()),
+			}
+		}
+		rt = append(rt, f)
 	}
-	return rt
+	return rt, nil
 }
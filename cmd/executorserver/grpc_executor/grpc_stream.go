@@ -2,6 +2,7 @@ package grpcexecutor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
@@ -100,6 +101,9 @@ func execStreamLoop(es pb.Executor_ExecStreamServer, errCh chan error, outCh cha
 
 		case rt := <-rtCh:
 			logger.Sugar().Debugf("response: %+v", rt)
+			if errors.Is(rt.Error, worker.ErrQueueFull) || errors.Is(rt.Error, worker.ErrShuttingDown) {
+				return workerError(rt.Error)
+			}
 			ret, err := model.ConvertResponse(rt, false)
 			if err != nil {
 				return status.Errorf(codes.Aborted, "response: %v", err)
@@ -16,6 +16,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/criyle/go-judge/cmd/executorserver/config"
@@ -64,13 +65,19 @@ func main() {
 	b := newEnvBuilder(conf)
 	envPool := newEnvPool(b, conf.EnableMetrics)
 	prefork(envPool, conf.PreFork)
-	work := newWorker(conf, envPool, fs)
+	tenants := loadTenants(conf)
+	audit := newAudit(conf)
+	work := newWorker(conf, envPool, fs, tenants, audit)
 	work.Start()
 	logger.Sugar().Infof("Starting worker with parallelism=%d, workdir=%s, timeLimitCheckInterval=%v",
 		conf.Parallelism, conf.Dir, conf.TimeLimitCheckerInterval)
 
 	// Init http handle
-	r := initHTTPMux(conf, work, fs)
+	tokens, err := tenants.authTokens(conf.AuthToken)
+	if err != nil {
+		log.Fatalln("load auth tokens failed", err)
+	}
+	r := initHTTPMux(conf, work, fs, tokens)
 	srv := http.Server{
 		Addr:    conf.HTTPAddr,
 		Handler: r,
@@ -89,7 +96,7 @@ func main() {
 	var grpcServer *grpc.Server
 	if conf.EnableGRPC {
 		esServer := grpcexecutor.New(work, fs, conf.SrcPrefix, logger)
-		grpcServer = newGRPCServer(conf, esServer)
+		grpcServer = newGRPCServer(conf, esServer, tokens)
 
 		lis, err := net.Listen("tcp", conf.GRPCAddr)
 		if err != nil {
@@ -106,19 +113,33 @@ func main() {
 	newForceGCWorker(conf)
 
 	// Graceful shutdown...
-	signal.Notify(sig, os.Interrupt)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
 	<-sig
-	signal.Reset(os.Interrupt)
+	signal.Reset(os.Interrupt, syscall.SIGTERM)
 
 	logger.Sugar().Info("Shutting Down...")
 
-	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*3)
+	ctx, cancel := context.WithTimeout(context.TODO(), conf.ShutdownGrace)
 	defer cancel()
 
 	var eg errgroup.Group
 	eg.Go(func() error {
+		// stop accepting new requests and let running requests finish within grace period
+		work.Drain(ctx, conf.DrainQueued)
 		work.Shutdown()
 		logger.Sugar().Info("Worker shutdown")
+
+		if audit != nil {
+			if err := audit.Close(); err != nil {
+				logger.Sugar().Warn("Audit log close: ", err)
+			}
+		}
+
+		if fsCleanUp != nil {
+			err := fsCleanUp()
+			logger.Sugar().Info("FileStore clean up")
+			return err
+		}
 		return nil
 	})
 
@@ -129,25 +150,22 @@ func main() {
 
 	if grpcServer != nil {
 		eg.Go(func() error {
-			grpcServer.GracefulStop()
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
 			logger.Sugar().Info("GRPC server shutdown")
 			return nil
 		})
 	}
 
-	if fsCleanUp != nil {
-		eg.Go(func() error {
-			err := fsCleanUp()
-			logger.Sugar().Info("FileStore clean up")
-			return err
-		})
-	}
-
-	go func() {
-		logger.Sugar().Info("Shutdown Finished: ", eg.Wait())
-		cancel()
-	}()
-	<-ctx.Done()
+	logger.Sugar().Info("Shutdown Finished: ", eg.Wait())
 }
 
 func loadConf() *config.Config {
@@ -212,7 +230,19 @@ func prefork(envPool worker.EnvironmentPool, prefork int) {
 	}
 }
 
-func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore) http.Handler {
+func loadTenants(conf *config.Config) *Tenants {
+	if conf.TenantConf == "" {
+		return &Tenants{}
+	}
+	t, err := readTenantConfig(conf.TenantConf)
+	if err != nil {
+		log.Fatalln("load tenant config failed", err)
+	}
+	logger.Sugar().Infof("Loaded %d tenants from %s", len(t.Tenants), conf.TenantConf)
+	return t
+}
+
+func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore, tokens map[string]authToken) http.Handler {
 	var r *gin.Engine
 	if conf.Release {
 		gin.SetMode(gin.ReleaseMode)
@@ -237,13 +267,13 @@ func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore
 	r.GET("/config", generateHandleConfig(conf))
 
 	// Add auth token
-	if conf.AuthToken != "" {
-		r.Use(tokenAuth(conf.AuthToken))
+	if len(tokens) > 0 {
+		r.Use(tokenAuth(tokens))
 		logger.Sugar().Info("Attach token auth with token:", conf.AuthToken)
 	}
 
 	// Rest Handle
-	restHandle := restexecutor.New(work, fs, conf.SrcPrefix, logger)
+	restHandle := restexecutor.New(work, fs, conf.SrcPrefix, conf.AuthToken != "", logger)
 	restHandle.Register(r)
 
 	// WebSocket Handle
@@ -257,7 +287,7 @@ func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore
 	return r
 }
 
-func newGRPCServer(conf *config.Config, esServer pb.ExecutorServer) *grpc.Server {
+func newGRPCServer(conf *config.Config, esServer pb.ExecutorServer, tokens map[string]authToken) *grpc.Server {
 	var grpcServer *grpc.Server
 	grpc_zap.ReplaceGrpcLoggerV2(logger)
 	streamMiddleware := []grpc.StreamServerInterceptor{
@@ -270,8 +300,8 @@ func newGRPCServer(conf *config.Config, esServer pb.ExecutorServer) *grpc.Server
 		grpc_zap.UnaryServerInterceptor(logger),
 		grpc_recovery.UnaryServerInterceptor(),
 	}
-	if conf.AuthToken != "" {
-		authFunc := grpcTokenAuth(conf.AuthToken)
+	if len(tokens) > 0 {
+		authFunc := grpcTokenAuth(tokens)
 		streamMiddleware = append(streamMiddleware, grpc_auth.StreamServerInterceptor(authFunc))
 		unaryMiddleware = append(unaryMiddleware, grpc_auth.UnaryServerInterceptor(authFunc))
 	}
@@ -293,29 +323,41 @@ func initGinMetrics(r *gin.Engine) {
 	p.Use(r)
 }
 
-func tokenAuth(token string) gin.HandlerFunc {
+// tokenAuth checks the bearer token and attaches the identity of the token to the request context
+func tokenAuth(tokens map[string]authToken) gin.HandlerFunc {
 	const bearer = "Bearer "
 	return func(c *gin.Context) {
 		reqToken := c.GetHeader("Authorization")
-		if strings.HasPrefix(reqToken, bearer) && reqToken[len(bearer):] == token {
-			c.Next()
-			return
+		if strings.HasPrefix(reqToken, bearer) {
+			if t, ok := tokens[reqToken[len(bearer):]]; ok {
+				c.Request = c.Request.WithContext(withAuthToken(c.Request.Context(), t))
+				c.Next()
+				return
+			}
 		}
 		c.AbortWithStatus(http.StatusUnauthorized)
 	}
 }
 
-func grpcTokenAuth(token string) func(context.Context) (context.Context, error) {
+func grpcTokenAuth(tokens map[string]authToken) func(context.Context) (context.Context, error) {
 	return func(ctx context.Context) (context.Context, error) {
 		reqToken, err := grpc_auth.AuthFromMD(ctx, "bearer")
 		if err != nil {
 			return nil, err
 		}
-		if reqToken != token {
+		t, ok := tokens[reqToken]
+		if !ok {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
 		}
-		return ctx, nil
+		return withAuthToken(ctx, t), nil
+	}
+}
+
+func withAuthToken(ctx context.Context, t authToken) context.Context {
+	if t.admin {
+		return worker.WithAdmin(ctx)
 	}
+	return worker.WithTenant(ctx, t.tenant)
 }
 
 func newFilsStore(dir string, fileTimeout time.Duration, enableMetrics bool) (filestore.FileStore, string, func() error, error) {
@@ -380,7 +422,28 @@ func newEnvPool(b pool.EnvBuilder, enableMetrics bool) worker.EnvironmentPool {
 	return p
 }
 
-func newWorker(conf *config.Config, envPool worker.EnvironmentPool, fs filestore.FileStore) worker.Worker {
+func newAudit(conf *config.Config) *auditLog {
+	if conf.AuditLog == "" {
+		return nil
+	}
+	a, err := newAuditLog(conf.AuditLog, int64(*conf.AuditLogMaxSize), conf.AuditLogMaxBackups, conf.AuditLogInline)
+	if err != nil {
+		log.Fatalln("open audit log failed", err)
+	}
+	logger.Sugar().Info("Audit log at ", conf.AuditLog)
+	return a
+}
+
+func newWorker(conf *config.Config, envPool worker.EnvironmentPool, fs filestore.FileStore, tenants *Tenants, audit *auditLog) worker.Worker {
+	tenantConf, defaultTenant := tenants.workerConfig()
+	var auditObserver func(*worker.Request, worker.Response)
+	if audit != nil {
+		auditObserver = audit.observe
+	}
+	retryCombine, err := worker.StringToRetryCombine(conf.RetryCombine)
+	if err != nil {
+		log.Fatalln("invalid retry policy", err)
+	}
 	return worker.New(worker.Config{
 		FileStore:             fs,
 		EnvironmentPool:       envPool,
@@ -390,8 +453,31 @@ func newWorker(conf *config.Config, envPool worker.EnvironmentPool, fs filestore
 		ExtraMemoryLimit:      *conf.ExtraMemoryLimit,
 		OutputLimit:           *conf.OutputLimit,
 		CopyOutLimit:          *conf.CopyOutLimit,
+		CopyOutDirLimit:       *conf.CopyOutDirLimit,
 		OpenFileLimit:         uint64(conf.OpenFileLimit),
+		ArchiveLimit:          *conf.ArchiveLimit,
+		ArchiveInodeLimit:     conf.ArchiveInodeLimit,
+		PriorityAging:         conf.PriorityAging,
+		QueueSize:             conf.QueueSize,
+		QueueBlocking:         conf.QueueBlocking,
 		ExecObserver:          execObserve,
+		AuditObserver:         auditObserver,
+		Tenants:               tenantConf,
+		DefaultTenant:         defaultTenant,
+		TenantObserver:        newTenantObserver(tenants),
+		ResultCacheSize:       *conf.ResultCacheSize,
+		ResultCacheAge:        conf.ResultCacheAge,
+		CacheObserver:         cacheObserve,
+		ParallelismObserver:   parallelismObserve,
+		ExecuteParallelism:    conf.ExecParallelism,
+		ExecuteQueueSize:      conf.ExecQueueSize,
+		ExecuteObserver:       executeObserve,
+		Retry: worker.RetryPolicy{
+			MaxAttempts:   conf.RetryAttempts,
+			TimeMargin:    conf.RetryTimeMargin,
+			InternalError: conf.RetryInternalError,
+			Combine:       retryCombine,
+		},
 	})
 }
 
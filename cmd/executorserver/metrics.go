@@ -101,6 +101,48 @@ var (
 		Name:      "environment_in_use",
 		Help:      "Total number of environment currently in use",
 	})
+
+	queueWaiting = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "queue_waiting",
+		Help:      "Number of requests waiting in the queue for each tenant",
+	}, []string{"tenant"})
+
+	queueRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "queue_running",
+		Help:      "Number of requests running for each tenant",
+	}, []string{"tenant"})
+
+	resultCacheCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "result_cache_total",
+		Help:      "Number of result cache lookups by hit or miss",
+	}, []string{"result"})
+
+	workerParallelism = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "worker_parallelism",
+		Help:      "Number of worker loops by target or current",
+	}, []string{"state"})
+
+	executeWaiting = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "execute_waiting",
+		Help:      "Number of streaming executions waiting for concurrency",
+	})
+
+	executeRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "execute_running",
+		Help:      "Number of streaming executions running",
+	})
+
+	executeRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "execute_rejected_total",
+		Help:      "Number of streaming executions rejected by the full queue",
+	})
 )
 
 func init() {
@@ -109,6 +151,10 @@ func init() {
 	prometheus.MustRegister(execMemHist, execMemSummary)
 	prometheus.MustRegister(fsSizeHist, fsSizeSummary, fsTotalSize)
 	prometheus.MustRegister(envCreated, envInUse)
+	prometheus.MustRegister(queueWaiting, queueRunning)
+	prometheus.MustRegister(resultCacheCount)
+	prometheus.MustRegister(workerParallelism)
+	prometheus.MustRegister(executeWaiting, executeRunning, executeRejected)
 }
 
 func execObserve(res worker.Response) {
@@ -127,6 +173,85 @@ func execObserve(res worker.Response) {
 	}
 }
 
+// defaultTenantLabel is the tenant label for the tenants not listed in the
+// tenant config to keep the number of series bounded
+const defaultTenantLabel = "default"
+
+// tenantObserver folds the tenants not listed into the default label and
+// deletes the series once the label has no waiting or running requests
+type tenantObserver struct {
+	mu    sync.Mutex
+	known map[string]bool
+	stats map[string]worker.TenantStats // stats of the tenants with requests
+}
+
+func newTenantObserver(tenants *Tenants) func(worker.TenantStats) {
+	o := &tenantObserver{
+		known: make(map[string]bool, len(tenants.Tenants)),
+		stats: make(map[string]worker.TenantStats),
+	}
+	for _, t := range tenants.Tenants {
+		o.known[t.Name] = true
+	}
+	return o.observe
+}
+
+func (o *tenantObserver) label(tenant string) string {
+	if o.known[tenant] {
+		return tenant
+	}
+	return defaultTenantLabel
+}
+
+func (o *tenantObserver) observe(s worker.TenantStats) {
+	label := o.label(s.Tenant)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if s.Waiting == 0 && s.Running == 0 {
+		delete(o.stats, s.Tenant)
+	} else {
+		o.stats[s.Tenant] = s
+	}
+
+	var waiting, running int
+	for t, st := range o.stats {
+		if o.label(t) == label {
+			waiting += st.Waiting
+			running += st.Running
+		}
+	}
+	if waiting == 0 && running == 0 {
+		queueWaiting.DeleteLabelValues(label)
+		queueRunning.DeleteLabelValues(label)
+		return
+	}
+	queueWaiting.WithLabelValues(label).Set(float64(waiting))
+	queueRunning.WithLabelValues(label).Set(float64(running))
+}
+
+func cacheObserve(hit bool) {
+	if hit {
+		resultCacheCount.WithLabelValues("hit").Inc()
+	} else {
+		resultCacheCount.WithLabelValues("miss").Inc()
+	}
+}
+
+func parallelismObserve(target, current int) {
+	workerParallelism.WithLabelValues("target").Set(float64(target))
+	workerParallelism.WithLabelValues("current").Set(float64(current))
+}
+
+func executeObserve(s worker.ExecuteStats) {
+	executeWaiting.Set(float64(s.Waiting))
+	executeRunning.Set(float64(s.Running))
+	if s.Rejected {
+		executeRejected.Inc()
+	}
+}
+
 var _ filestore.FileStore = &metricsFileStore{}
 
 type metricsFileStore struct {
@@ -0,0 +1,392 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/worker"
+)
+
+// AuditRecord defines a request together with its response in the audit log.
+// The request is in the format of /run so that it can be replayed
+type AuditRecord struct {
+	Time     time.Time     `json:"time"`
+	Request  Request       `json:"request"`
+	Response AuditResponse `json:"response"`
+}
+
+// AuditResponse defines the recorded response
+type AuditResponse struct {
+	Results  []AuditResult `json:"results"`
+	ErrorMsg string        `json:"error,omitempty"`
+}
+
+// AuditResult defines the recorded result, files are either inlined or hashed
+type AuditResult struct {
+	Status     Status            `json:"status"`
+	ExitStatus int               `json:"exitStatus"`
+	Error      string            `json:"error,omitempty"`
+	Time       uint64            `json:"time"`
+	Memory     uint64            `json:"memory"`
+	RunTime    uint64            `json:"runTime"`
+	Files      map[string]string `json:"files,omitempty"`
+	FileHashes map[string]string `json:"fileHashes,omitempty"`
+	FileIDs    map[string]string `json:"fileIds,omitempty"`
+}
+
+// ConvertAuditRecord converts the worker request and response into the audit record,
+// file contents are inlined if inline or replaced by their sha256 otherwise.
+// The files of the response are read without changing their offsets and they
+// are hashed instead once their total size exceeds inlineMax (if > 0)
+func ConvertAuditRecord(req *worker.Request, rt worker.Response, inline bool, inlineMax int64) AuditRecord {
+	a := &auditConverter{inline: inline, inlineMax: inlineMax}
+	r := AuditRecord{
+		Time: time.Now(),
+		Request: Request{
+			RequestID:   req.RequestID,
+			Cmd:         a.cmds(req.Cmd),
+			PipeMapping: auditPipeMapping(req.PipeMapping),
+			GroupLimit:  auditGroupLimit(req.GroupLimit),
+			GroupPolicy: auditGroupPolicy(req.GroupPolicy),
+			KillOnExit:  req.KillOnExit,
+			Priority:    req.Priority,
+			Tenant:      req.Tenant,
+			Batch:       a.batch(req.Batch),
+			Interactive: a.interactive(req.Interactive),
+		},
+		Response: AuditResponse{
+			Results: make([]AuditResult, 0, len(rt.Results)),
+		},
+	}
+	for _, s := range req.Stages {
+		r.Request.Stages = append(r.Request.Stages, Stage{
+			Cmd:         a.cmds(s.Cmd),
+			PipeMapping: auditPipeMapping(s.PipeMapping),
+			GroupLimit:  auditGroupLimit(s.GroupLimit),
+			GroupPolicy: auditGroupPolicy(s.GroupPolicy),
+			KillOnExit:  s.KillOnExit,
+			Batch:       a.batch(s.Batch),
+			Interactive: a.interactive(s.Interactive),
+		})
+	}
+	if rt.Error != nil {
+		r.Response.ErrorMsg = rt.Error.Error()
+	}
+	for _, res := range rt.Results {
+		r.Response.Results = append(r.Response.Results, a.result(res))
+	}
+	return r
+}
+
+// ContentHashPrefix prefixes the hex encoded sha256 of the file content
+const ContentHashPrefix = "sha256:"
+
+type auditConverter struct {
+	inline    bool
+	inlineMax int64
+	inlined   int64 // inlined is the total size of the inlined result files
+}
+
+func (a *auditConverter) result(r worker.Result) AuditResult {
+	res := AuditResult{
+		Status:     Status(r.Status),
+		ExitStatus: r.ExitStatus,
+		Error:      r.Error,
+		Time:       uint64(r.Time),
+		RunTime:    uint64(r.RunTime),
+		Memory:     uint64(r.Memory),
+		FileIDs:    r.FileIDs,
+	}
+	for name, f := range r.Files {
+		if b, ok := a.readInline(f); ok {
+			if res.Files == nil {
+				res.Files = make(map[string]string)
+			}
+			res.Files[name] = string(b)
+			continue
+		}
+		if res.FileHashes == nil {
+			res.FileHashes = make(map[string]string)
+		}
+		h, err := hashAtFile(f)
+		if err != nil {
+			continue
+		}
+		res.FileHashes[name] = h
+	}
+	return res
+}
+
+func (a *auditConverter) cmds(cs []worker.Cmd) []Cmd {
+	if cs == nil {
+		return nil
+	}
+	rt := make([]Cmd, 0, len(cs))
+	for _, c := range cs {
+		rt = append(rt, a.cmd(c))
+	}
+	return rt
+}
+
+func (a *auditConverter) cmd(c worker.Cmd) Cmd {
+	rt := Cmd{
+		Args:              c.Args,
+		Env:               c.Env,
+		Files:             make([]*CmdFile, 0, len(c.Files)),
+		TTY:               c.TTY,
+		CPULimit:          uint64(c.CPULimit),
+		ClockLimit:        uint64(c.ClockLimit),
+		IdleLimit:         uint64(c.IdleLimit),
+		MemoryLimit:       uint64(c.MemoryLimit),
+		StackLimit:        uint64(c.StackLimit),
+		ProcLimit:         c.ProcLimit,
+		CPURateLimit:      c.CPURateLimit,
+		CPUSetLimit:       c.CPUSetLimit,
+		StrictMemoryLimit: c.StrictMemoryLimit,
+		CopyOut:           auditCopyOut(c.CopyOut),
+		CopyOutCached:     auditCopyOut(c.CopyOutCached),
+		CopyOutArchive:    auditCopyOutArchive(c.CopyOut, c.CopyOutCached),
+		CopyOutMax:        c.CopyOutMax,
+		CopyOutDir:        c.CopyOutDir,
+		CopyOutDirMax:     c.CopyOutDirMax,
+		CopyOutStage:      auditCopyOut(c.CopyOutStage),
+		NoCache:           c.NoCache,
+		UsageTimeline:     c.UsageTimeline,
+	}
+	for _, f := range c.Files {
+		rt.Files = append(rt.Files, a.file(f))
+	}
+	if len(c.CopyIn) > 0 {
+		rt.CopyIn = make(map[string]CmdFile, len(c.CopyIn))
+		for name, f := range c.CopyIn {
+			if cf := a.file(f); cf != nil {
+				rt.CopyIn[name] = *cf
+			}
+		}
+	}
+	if c.Compare != nil {
+		rt.Compare = &Compare{
+			Expected:     a.file(c.Compare.Expected),
+			Mode:         c.Compare.Mode.String(),
+			AbsTolerance: c.Compare.AbsTolerance,
+			RelTolerance: c.Compare.RelTolerance,
+		}
+	}
+	if c.Checker != nil {
+		rt.Checker = &Checker{
+			Cmd:    a.cmd(c.Checker.Cmd),
+			Input:  a.file(c.Checker.Input),
+			Answer: a.file(c.Checker.Answer),
+		}
+	}
+	return rt
+}
+
+func (a *auditConverter) file(f worker.CmdFile) *CmdFile {
+	switch f := f.(type) {
+	case nil:
+		return nil
+	case *worker.LocalFile:
+		return &CmdFile{Src: &f.Src}
+	case *worker.MemoryFile:
+		if a.inline {
+			s := string(f.Content)
+			return &CmdFile{Content: &s}
+		}
+		h := sha256.Sum256(f.Content)
+		s := ContentHashPrefix + hex.EncodeToString(h[:])
+		return &CmdFile{ContentHash: &s}
+	case *worker.CachedFile:
+		return &CmdFile{FileID: &f.FileID}
+	case *worker.StageFile:
+		return &CmdFile{StageFile: &f.Name}
+	case *worker.CopyInFile:
+		cf := a.file(f.Source)
+		if cf == nil {
+			return nil
+		}
+		mode := uint32(f.Mode)
+		cf.Mode, cf.VerifyUnchanged = &mode, f.VerifyUnchanged
+		return cf
+	case *worker.ArchiveFile:
+		return &CmdFile{Archive: &ArchiveFile{
+			File:       a.file(f.Source),
+			Format:     f.Format.String(),
+			SizeLimit:  int64(f.Limit),
+			InodeLimit: f.InodeLimit,
+		}}
+	case *worker.Collector:
+		max := int64(f.Max)
+		return &CmdFile{Name: &f.Name, Max: &max, Pipe: f.Pipe, Tail: int64(f.Tail)}
+	default:
+		// streams cannot be replayed, only the name is recorded
+		s := f.String()
+		return &CmdFile{Name: &s}
+	}
+}
+
+func (a *auditConverter) batch(b *worker.Batch) *Batch {
+	if b == nil {
+		return nil
+	}
+	rt := &Batch{
+		Cmd:           a.cmd(b.Cmd),
+		Cases:         make([]BatchCase, 0, len(b.Cases)),
+		StopOnFailure: b.StopOnFailure,
+	}
+	for _, bc := range b.Cases {
+		rt.Cases = append(rt.Cases, BatchCase{
+			Stdin:       a.file(bc.Stdin),
+			Expected:    a.file(bc.Expected),
+			Answer:      a.file(bc.Answer),
+			CPULimit:    uint64(bc.CPULimit),
+			ClockLimit:  uint64(bc.ClockLimit),
+			MemoryLimit: uint64(bc.MemoryLimit),
+		})
+	}
+	return rt
+}
+
+func (a *auditConverter) interactive(it *worker.Interactive) *Interactive {
+	if it == nil {
+		return nil
+	}
+	return &Interactive{
+		Solution:      a.cmd(it.Solution),
+		Interactor:    a.cmd(it.Interactor),
+		TranscriptMax: int64(it.TranscriptMax),
+	}
+}
+
+func auditGroupLimit(l worker.GroupLimit) *GroupLimit {
+	if l == (worker.GroupLimit{}) {
+		return nil
+	}
+	return &GroupLimit{
+		CPULimit:    uint64(l.Time),
+		MemoryLimit: uint64(l.Memory),
+		ProcLimit:   l.Proc,
+	}
+}
+
+func auditGroupPolicy(p worker.GroupPolicy) string {
+	if p == envexec.GroupWaitAll {
+		return ""
+	}
+	return p.String()
+}
+
+func auditPipeMapping(pm []worker.PipeMap) []PipeMap {
+	if pm == nil {
+		return nil
+	}
+	rt := make([]PipeMap, 0, len(pm))
+	for _, p := range pm {
+		rt = append(rt, PipeMap{
+			In:    PipeIndex{Index: p.In.Index, Fd: p.In.Fd},
+			Out:   PipeIndex{Index: p.Out.Index, Fd: p.Out.Fd},
+			Name:  p.Name,
+			Max:   int64(p.Limit),
+			Proxy: p.Proxy,
+
+			Transcript: p.Transcript,
+			FanOut:     auditPipeIndexes(p.FanOut),
+		})
+	}
+	return rt
+}
+
+func auditPipeIndexes(pi []worker.PipeIndex) []PipeIndex {
+	if len(pi) == 0 {
+		return nil
+	}
+	rt := make([]PipeIndex, 0, len(pi))
+	for _, p := range pi {
+		rt = append(rt, PipeIndex{Index: p.Index, Fd: p.Fd})
+	}
+	return rt
+}
+
+func auditCopyOut(files []worker.CmdCopyOutFile) []string {
+	if files == nil {
+		return nil
+	}
+	rt := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.Archive != nil {
+			continue
+		}
+		if f.Optional {
+			rt = append(rt, f.Name+optionalSuffix)
+		} else {
+			rt = append(rt, f.Name)
+		}
+	}
+	return rt
+}
+
+func auditCopyOutArchive(copyOut, copyOutCached []worker.CmdCopyOutFile) []CopyOutArchive {
+	var rt []CopyOutArchive
+	for i, files := range [][]worker.CmdCopyOutFile{copyOut, copyOutCached} {
+		for _, f := range files {
+			if f.Archive == nil {
+				continue
+			}
+			rt = append(rt, CopyOutArchive{
+				Name:     f.Name,
+				Format:   f.Archive.Format.String(),
+				Patterns: f.Archive.Patterns,
+				MaxFiles: f.Archive.FileLimit,
+				Optional: f.Optional,
+				Cached:   i == 1,
+			})
+		}
+	}
+	return rt
+}
+
+// readInline reads the result file to inline if the inline size allows
+func (a *auditConverter) readInline(f *os.File) ([]byte, bool) {
+	if !a.inline {
+		return nil, false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, false
+	}
+	if a.inlineMax > 0 && a.inlined+fi.Size() > a.inlineMax {
+		return nil, false
+	}
+	b, err := readAtFile(f, fi.Size())
+	if err != nil {
+		return nil, false
+	}
+	a.inlined += int64(len(b))
+	return b, true
+}
+
+// readAtFile reads the file up to size without changing its offset
+func readAtFile(f *os.File, size int64) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(io.NewSectionReader(f, 0, size), b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// hashAtFile returns the sha256 of the file without changing its offset
+func hashAtFile(f *os.File) (string, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(f, 0, fi.Size())); err != nil {
+		return "", err
+	}
+	return ContentHashPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,14 +13,47 @@ import (
 	"github.com/criyle/go-judge/worker"
 )
 
-// CmdFile defines file from multiple source including local / memory / cached or pipe collector
+// CmdFile defines file from multiple source including local / memory / cached / stage or pipe collector
 type CmdFile struct {
-	Src     *string `json:"src"`
-	Content *string `json:"content"`
-	FileID  *string `json:"fileId"`
-	Name    *string `json:"name"`
-	Max     *int64  `json:"max"`
-	Pipe    bool    `json:"pipe"`
+	Src       *string `json:"src"`
+	Content   *string `json:"content"`
+	FileID    *string `json:"fileId"`
+	StageFile *string `json:"stageFile"`
+	Name      *string `json:"name"`
+	Max       *int64  `json:"max"`
+	Pipe      bool    `json:"pipe"`
+	// Tail keeps the last bytes after the truncated marker if the output exceeds max
+	Tail int64 `json:"tail,omitempty"`
+
+	// Archive is extracted into the directory of the copyIn name
+	Archive *ArchiveFile `json:"archive"`
+
+	// Mode creates the copyIn file with the permission (0777 by default) and
+	// VerifyUnchanged reports file error if it was modified or deleted after the run
+	Mode            *uint32 `json:"mode,omitempty"`
+	VerifyUnchanged bool    `json:"verifyUnchanged,omitempty"`
+
+	// ContentHash is recorded by the audit log instead of the content, it cannot be run
+	ContentHash *string `json:"contentHash,omitempty"`
+}
+
+// ArchiveFile defines archive (tar / tar.gz / zip) from the file (src / content / fileId)
+type ArchiveFile struct {
+	File       *CmdFile `json:"file"`
+	Format     string   `json:"format"`
+	SizeLimit  int64    `json:"sizeLimit"`
+	InodeLimit int      `json:"inodeLimit"`
+}
+
+// CopyOutArchive defines directory packed into archive (tar / tar.gz / zip)
+type CopyOutArchive struct {
+	Name     string   `json:"name"`
+	Format   string   `json:"format"`
+	Patterns []string `json:"patterns,omitempty"`
+	MaxFiles int      `json:"maxFiles,omitempty"`
+	Optional bool     `json:"optional,omitempty"`
+	// Cached stores the archive into the file store as copyOutCached
+	Cached bool `json:"cached,omitempty"`
 }
 
 // Cmd defines command and limits to start a program using in envexec
@@ -31,6 +66,7 @@ type Cmd struct {
 	CPULimit          uint64 `json:"cpuLimit"`
 	RealCPULimit      uint64 `json:"realCpuLimit"`
 	ClockLimit        uint64 `json:"clockLimit"`
+	IdleLimit         uint64 `json:"idleLimit"`
 	MemoryLimit       uint64 `json:"memoryLimit"`
 	StackLimit        uint64 `json:"stackLimit"`
 	ProcLimit         uint64 `json:"procLimit"`
@@ -44,6 +80,32 @@ type Cmd struct {
 	CopyOutCached []string `json:"copyOutCached"`
 	CopyOutMax    uint64   `json:"copyOutMax"`
 	CopyOutDir    string   `json:"copyOutDir"`
+	CopyOutDirMax uint64   `json:"copyOutDirMax,omitempty"`
+	CopyOutStage  []string `json:"copyOutStage"`
+
+	// CopyOutArchive packs directories into archives copied out or cached
+	CopyOutArchive []CopyOutArchive `json:"copyOutArchive,omitempty"`
+
+	Compare *Compare `json:"compare"`
+	Checker *Checker `json:"checker"`
+	NoCache bool     `json:"noCache"`
+
+	UsageTimeline bool `json:"usageTimeline"`
+}
+
+// Checker defines the special judge to run after the cmd accepted
+type Checker struct {
+	Cmd    Cmd      `json:"cmd"`
+	Input  *CmdFile `json:"input"`
+	Answer *CmdFile `json:"answer"`
+}
+
+// Compare defines the expected output and the compare mode of the collected stdout
+type Compare struct {
+	Expected     *CmdFile `json:"expected"`
+	Mode         string   `json:"mode"`
+	AbsTolerance float64  `json:"absTolerance"`
+	RelTolerance float64  `json:"relTolerance"`
 }
 
 // PipeIndex defines indexing for a pipe fd
@@ -59,13 +121,66 @@ type PipeMap struct {
 	Name  string    `json:"name"`
 	Max   int64     `json:"max"`
 	Proxy bool      `json:"proxy"`
+
+	Transcript bool        `json:"transcript,omitempty"`
+	FanOut     []PipeIndex `json:"fanOut,omitempty"`
+}
+
+// GroupLimit defines the aggregate limits of all cmd in the group
+type GroupLimit struct {
+	CPULimit    uint64 `json:"cpuLimit"`
+	MemoryLimit uint64 `json:"memoryLimit"`
+	ProcLimit   uint64 `json:"procLimit"`
+}
+
+// Stage defines a stage of the multi-stage request
+type Stage struct {
+	Cmd         []Cmd        `json:"cmd"`
+	PipeMapping []PipeMap    `json:"pipeMapping"`
+	GroupLimit  *GroupLimit  `json:"groupLimit,omitempty"`
+	GroupPolicy string       `json:"groupPolicy,omitempty"`
+	KillOnExit  []int        `json:"killOnExit,omitempty"`
+	Batch       *Batch       `json:"batch"`
+	Interactive *Interactive `json:"interactive"`
+}
+
+// Interactive defines the solution and the interactor connected by pipes both ways
+type Interactive struct {
+	Solution      Cmd   `json:"solution"`
+	Interactor    Cmd   `json:"interactor"`
+	TranscriptMax int64 `json:"transcriptMax"`
+}
+
+// Batch defines test cases to run one after another inside the same environment
+type Batch struct {
+	Cmd           Cmd         `json:"cmd"`
+	Cases         []BatchCase `json:"cases"`
+	StopOnFailure bool        `json:"stopOnFailure"`
+}
+
+// BatchCase defines the stdin and limits override for a single case
+type BatchCase struct {
+	Stdin       *CmdFile `json:"stdin"`
+	Expected    *CmdFile `json:"expected"`
+	Answer      *CmdFile `json:"answer"`
+	CPULimit    uint64   `json:"cpuLimit"`
+	ClockLimit  uint64   `json:"clockLimit"`
+	MemoryLimit uint64   `json:"memoryLimit"`
 }
 
 // Request defines single worker request
 type Request struct {
-	RequestID   string    `json:"requestId"`
-	Cmd         []Cmd     `json:"cmd"`
-	PipeMapping []PipeMap `json:"pipeMapping"`
+	RequestID   string       `json:"requestId"`
+	Cmd         []Cmd        `json:"cmd"`
+	PipeMapping []PipeMap    `json:"pipeMapping"`
+	GroupLimit  *GroupLimit  `json:"groupLimit,omitempty"`
+	GroupPolicy string       `json:"groupPolicy,omitempty"`
+	KillOnExit  []int        `json:"killOnExit,omitempty"`
+	Priority    int          `json:"priority"`
+	Tenant      string       `json:"tenant"`
+	Batch       *Batch       `json:"batch"`
+	Interactive *Interactive `json:"interactive"`
+	Stages      []Stage      `json:"stages"`
 }
 
 // Status offers JSON marshal for envexec.Status
@@ -98,16 +213,59 @@ type Result struct {
 	Files      map[string]string   `json:"files,omitempty"`
 	FileIDs    map[string]string   `json:"fileIds,omitempty"`
 	FileError  []envexec.FileError `json:"fileError,omitempty"`
+	Diff       string              `json:"diff,omitempty"`
+
+	Score          float64 `json:"score,omitempty"`
+	CheckerMessage string  `json:"checkerMessage,omitempty"`
+
+	UsageTimeline *UsageTimeline `json:"usageTimeline,omitempty"`
+	Attempts      []Attempt      `json:"attempts,omitempty"`
+
+	// CopyOutDir is the manifest of the files visited by copyOutDir
+	CopyOutDir []CopyOutDirEntry `json:"copyOutDir,omitempty"`
+	// OutputSize is the number of bytes written by the program to each collector
+	OutputSize map[string]uint64 `json:"outputSize,omitempty"`
+	// GroupLimitExceeded is the aggregate limit of the group that was exceeded
+	GroupLimitExceeded string `json:"groupLimitExceeded,omitempty"`
+	// Killed indicates the cmd was terminated because another cmd in the group exited
+	Killed bool `json:"killed,omitempty"`
 
 	files []string
 	Buffs map[string][]byte `json:"-"`
 }
 
+// Attempt defines the status and the usage of a run by the retry policy
+type Attempt struct {
+	Status  Status `json:"status"`
+	Time    uint64 `json:"time"`
+	RunTime uint64 `json:"runTime"`
+	Memory  uint64 `json:"memory"`
+}
+
+// CopyOutDirEntry defines a file or a directory visited by copyOutDir, the
+// file is not dumped if skipped is not empty
+type CopyOutDirEntry struct {
+	Name    string `json:"name"`
+	Mode    string `json:"mode"`
+	Size    uint64 `json:"size"`
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// UsageTimeline defines the usage samples in columns, elapsed time (ns),
+// cpu time (ns) and memory (byte) of the same index belong to the same sample
+type UsageTimeline struct {
+	Elapsed []uint64 `json:"elapsed"`
+	Time    []uint64 `json:"time"`
+	Memory  []uint64 `json:"memory"`
+}
+
 // Response defines worker response for single request
 type Response struct {
 	RequestID string   `json:"requestId"`
 	Results   []Result `json:"results"`
 	ErrorMsg  string   `json:"error,omitempty"`
+	ErrorCode int      `json:"errorCode,omitempty"`
+	WaitTime  uint64   `json:"waitTime,omitempty"`
 
 	mmap bool
 }
@@ -152,6 +310,7 @@ func ConvertResponse(r worker.Response, mmap bool) (ret Response, err error) {
 	ret = Response{
 		RequestID: r.RequestID,
 		Results:   make([]Result, 0, len(r.Results)),
+		WaitTime:  uint64(r.WaitTime),
 		mmap:      mmap,
 	}
 	for _, r := range r.Results {
@@ -163,17 +322,38 @@ func ConvertResponse(r worker.Response, mmap bool) (ret Response, err error) {
 	}
 	if r.Error != nil {
 		ret.ErrorMsg = r.Error.Error()
+		ret.ErrorCode = ErrorCode(r.Error)
 	}
 	return ret, nil
 }
 
+// ErrorCode maps the worker error into HTTP status code
+func ErrorCode(err error) int {
+	if errors.Is(err, worker.ErrQueueFull) {
+		return http.StatusTooManyRequests
+	}
+	if errors.Is(err, worker.ErrShuttingDown) || errors.Is(err, worker.ErrCancelled) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
 // ConvertRequest converts json request into worker request
 func ConvertRequest(r *Request, srcPrefix string) (*worker.Request, error) {
 	req := &worker.Request{
 		RequestID:   r.RequestID,
 		Cmd:         make([]worker.Cmd, 0, len(r.Cmd)),
 		PipeMapping: make([]worker.PipeMap, 0, len(r.PipeMapping)),
+		GroupLimit:  convertGroupLimit(r.GroupLimit),
+		KillOnExit:  r.KillOnExit,
+		Priority:    r.Priority,
+		Tenant:      r.Tenant,
+	}
+	policy, err := envexec.StringToGroupPolicy(r.GroupPolicy)
+	if err != nil {
+		return nil, err
 	}
+	req.GroupPolicy = policy
 	for _, c := range r.Cmd {
 		wc, err := convertCmd(c, srcPrefix)
 		if err != nil {
@@ -184,9 +364,112 @@ func ConvertRequest(r *Request, srcPrefix string) (*worker.Request, error) {
 	for _, p := range r.PipeMapping {
 		req.PipeMapping = append(req.PipeMapping, convertPipe(p))
 	}
+	if r.Batch != nil {
+		b, err := convertBatch(r.Batch, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		req.Batch = b
+	}
+	if r.Interactive != nil {
+		it, err := convertInteractive(r.Interactive, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		req.Interactive = it
+	}
+	for _, s := range r.Stages {
+		ws := worker.Stage{
+			Cmd:         make([]worker.Cmd, 0, len(s.Cmd)),
+			PipeMapping: make([]worker.PipeMap, 0, len(s.PipeMapping)),
+			GroupLimit:  convertGroupLimit(s.GroupLimit),
+			KillOnExit:  s.KillOnExit,
+		}
+		policy, err := envexec.StringToGroupPolicy(s.GroupPolicy)
+		if err != nil {
+			return nil, err
+		}
+		ws.GroupPolicy = policy
+		for _, c := range s.Cmd {
+			wc, err := convertCmd(c, srcPrefix)
+			if err != nil {
+				return nil, err
+			}
+			ws.Cmd = append(ws.Cmd, wc)
+		}
+		for _, p := range s.PipeMapping {
+			ws.PipeMapping = append(ws.PipeMapping, convertPipe(p))
+		}
+		if s.Batch != nil {
+			b, err := convertBatch(s.Batch, srcPrefix)
+			if err != nil {
+				return nil, err
+			}
+			ws.Batch = b
+		}
+		if s.Interactive != nil {
+			it, err := convertInteractive(s.Interactive, srcPrefix)
+			if err != nil {
+				return nil, err
+			}
+			ws.Interactive = it
+		}
+		req.Stages = append(req.Stages, ws)
+	}
 	return req, nil
 }
 
+func convertBatch(b *Batch, srcPrefix string) (*worker.Batch, error) {
+	c, err := convertCmd(b.Cmd, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	wb := &worker.Batch{
+		Cmd:           c,
+		Cases:         make([]worker.BatchCase, 0, len(b.Cases)),
+		StopOnFailure: b.StopOnFailure,
+	}
+	for _, bc := range b.Cases {
+		stdin, err := convertCmdFile(bc.Stdin, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := convertCmdFile(bc.Expected, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		answer, err := convertCopyInFile(bc.Answer, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		wb.Cases = append(wb.Cases, worker.BatchCase{
+			Stdin:       stdin,
+			Expected:    expected,
+			Answer:      answer,
+			CPULimit:    time.Duration(bc.CPULimit),
+			ClockLimit:  time.Duration(bc.ClockLimit),
+			MemoryLimit: envexec.Size(bc.MemoryLimit),
+		})
+	}
+	return wb, nil
+}
+
+func convertInteractive(it *Interactive, srcPrefix string) (*worker.Interactive, error) {
+	solution, err := convertCmd(it.Solution, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	interactor, err := convertCmd(it.Interactor, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &worker.Interactive{
+		Solution:      solution,
+		Interactor:    interactor,
+		TranscriptMax: envexec.Size(it.TranscriptMax),
+	}, nil
+}
+
 func convertResult(r worker.Result, mmap bool) (Result, error) {
 	res := Result{
 		Status:     Status(r.Status),
@@ -197,6 +480,17 @@ func convertResult(r worker.Result, mmap bool) (Result, error) {
 		Memory:     uint64(r.Memory),
 		FileIDs:    r.FileIDs,
 		FileError:  r.FileError,
+		Diff:       r.Diff,
+
+		Score:          r.Score,
+		CheckerMessage: r.CheckerMessage,
+		UsageTimeline:  convertUsageTimeline(r.UsageTimeline),
+		Attempts:       convertAttempts(r.Attempts),
+		CopyOutDir:     convertCopyOutDir(r.CopyOutDir),
+		OutputSize:     convertOutputSize(r.OutputSize),
+
+		GroupLimitExceeded: r.GroupLimitExceeded.String(),
+		Killed:             r.Killed,
 	}
 	if r.Files != nil {
 		res.Files = make(map[string]string)
@@ -215,6 +509,77 @@ func convertResult(r worker.Result, mmap bool) (Result, error) {
 	return res, nil
 }
 
+func convertAttempts(a []worker.Attempt) []Attempt {
+	if len(a) == 0 {
+		return nil
+	}
+	rt := make([]Attempt, 0, len(a))
+	for _, at := range a {
+		rt = append(rt, Attempt{
+			Status:  Status(at.Status),
+			Time:    uint64(at.Time),
+			RunTime: uint64(at.RunTime),
+			Memory:  uint64(at.Memory),
+		})
+	}
+	return rt
+}
+
+func convertOutputSize(s map[string]envexec.Size) map[string]uint64 {
+	if len(s) == 0 {
+		return nil
+	}
+	rt := make(map[string]uint64, len(s))
+	for k, v := range s {
+		rt[k] = uint64(v)
+	}
+	return rt
+}
+
+func convertCopyOutDir(m []envexec.CopyOutDirEntry) []CopyOutDirEntry {
+	if len(m) == 0 {
+		return nil
+	}
+	rt := make([]CopyOutDirEntry, 0, len(m))
+	for _, e := range m {
+		rt = append(rt, CopyOutDirEntry{
+			Name:    e.Name,
+			Mode:    e.Mode.String(),
+			Size:    uint64(e.Size),
+			Skipped: e.Skipped,
+		})
+	}
+	return rt
+}
+
+func convertUsageTimeline(s []worker.UsageSample) *UsageTimeline {
+	if len(s) == 0 {
+		return nil
+	}
+	t := &UsageTimeline{
+		Elapsed: make([]uint64, 0, len(s)),
+		Time:    make([]uint64, 0, len(s)),
+		Memory:  make([]uint64, 0, len(s)),
+	}
+	for _, u := range s {
+		t.Elapsed = append(t.Elapsed, uint64(u.Elapsed))
+		t.Time = append(t.Time, uint64(u.Time))
+		t.Memory = append(t.Memory, uint64(u.Memory))
+	}
+	return t
+}
+
+func convertGroupLimit(l *GroupLimit) worker.GroupLimit {
+	if l == nil {
+		return worker.GroupLimit{}
+	}
+	return worker.GroupLimit{
+		Time:   time.Duration(l.CPULimit),
+		Memory: envexec.Size(l.MemoryLimit),
+		Proc:   l.ProcLimit,
+	}
+}
+
 func convertPipe(p PipeMap) worker.PipeMap {
 	return worker.PipeMap{
 		In: worker.PipeIndex{
@@ -225,12 +590,25 @@ func convertPipe(p PipeMap) worker.PipeMap {
 			Index: p.Out.Index,
 			Fd:    p.Out.Fd,
 		},
-		Proxy: p.Proxy,
-		Name:  p.Name,
-		Limit: worker.Size(p.Max),
+		Proxy:      p.Proxy,
+		Name:       p.Name,
+		Limit:      worker.Size(p.Max),
+		Transcript: p.Transcript,
+		FanOut:     convertPipeIndexes(p.FanOut),
 	}
 }
 
+func convertPipeIndexes(pi []PipeIndex) []worker.PipeIndex {
+	if len(pi) == 0 {
+		return nil
+	}
+	rt := make([]worker.PipeIndex, 0, len(pi))
+	for _, p := range pi {
+		rt = append(rt, worker.PipeIndex{Index: p.Index, Fd: p.Fd})
+	}
+	return rt
+}
+
 func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 	clockLimit := c.ClockLimit
 	if c.RealCPULimit > 0 {
@@ -243,6 +621,7 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 		TTY:               c.TTY,
 		CPULimit:          time.Duration(c.CPULimit),
 		ClockLimit:        time.Duration(clockLimit),
+		IdleLimit:         time.Duration(c.IdleLimit),
 		MemoryLimit:       envexec.Size(c.MemoryLimit),
 		StackLimit:        envexec.Size(c.StackLimit),
 		ProcLimit:         c.ProcLimit,
@@ -253,6 +632,10 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 		CopyOutCached:     convertCopyOut(c.CopyOutCached),
 		CopyOutMax:        c.CopyOutMax,
 		CopyOutDir:        c.CopyOutDir,
+		CopyOutDirMax:     c.CopyOutDirMax,
+		CopyOutStage:      convertCopyOut(c.CopyOutStage),
+		NoCache:           c.NoCache,
+		UsageTimeline:     c.UsageTimeline,
 	}
 	for _, f := range c.Files {
 		cf, err := convertCmdFile(f, srcPrefix)
@@ -261,20 +644,97 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 		}
 		w.Files = append(w.Files, cf)
 	}
+	for _, a := range c.CopyOutArchive {
+		format, err := envexec.StringToArchiveFormat(a.Format)
+		if err != nil {
+			return w, err
+		}
+		f := worker.CmdCopyOutFile{
+			Name:     a.Name,
+			Optional: a.Optional,
+			Archive: &envexec.CopyOutArchive{
+				Format:    format,
+				Patterns:  a.Patterns,
+				FileLimit: a.MaxFiles,
+			},
+		}
+		if a.Cached {
+			w.CopyOutCached = append(w.CopyOutCached, f)
+		} else {
+			w.CopyOut = append(w.CopyOut, f)
+		}
+	}
 	if c.CopyIn != nil {
 		w.CopyIn = make(map[string]worker.CmdFile)
 		for k, f := range c.CopyIn {
-			cf, err := convertCmdFile(&f, srcPrefix)
+			cf, err := convertCopyInFile(&f, srcPrefix)
 			if err != nil {
 				return w, err
 			}
 			w.CopyIn[k] = cf
 		}
 	}
+	if c.Compare != nil {
+		expected, err := convertCmdFile(c.Compare.Expected, srcPrefix)
+		if err != nil {
+			return w, err
+		}
+		mode, err := worker.StringToCompareMode(c.Compare.Mode)
+		if err != nil {
+			return w, err
+		}
+		w.Compare = &worker.Compare{
+			Expected:     expected,
+			Mode:         mode,
+			AbsTolerance: c.Compare.AbsTolerance,
+			RelTolerance: c.Compare.RelTolerance,
+		}
+	}
+	if c.Checker != nil {
+		ckCmd, err := convertCmd(c.Checker.Cmd, srcPrefix)
+		if err != nil {
+			return w, err
+		}
+		input, err := convertCopyInFile(c.Checker.Input, srcPrefix)
+		if err != nil {
+			return w, err
+		}
+		answer, err := convertCopyInFile(c.Checker.Answer, srcPrefix)
+		if err != nil {
+			return w, err
+		}
+		w.Checker = &worker.Checker{
+			Cmd:    ckCmd,
+			Input:  input,
+			Answer: answer,
+		}
+	}
 	return w, nil
 }
 
+// convertCmdFile converts the file used other than copyIn (e.g. files), which
+// does not support mode and verifyUnchanged
 func convertCmdFile(f *CmdFile, srcPrefix string) (worker.CmdFile, error) {
+	if f != nil && (f.Mode != nil || f.VerifyUnchanged) {
+		return nil, fmt.Errorf("file: mode and verifyUnchanged are only supported by copyIn")
+	}
+	return convertCmdFileSource(f, srcPrefix)
+}
+
+// convertCopyInFile converts the file copied into the work dir
+func convertCopyInFile(f *CmdFile, srcPrefix string) (worker.CmdFile, error) {
+	cf, err := convertCmdFileSource(f, srcPrefix)
+	if err != nil || f == nil || (f.Mode == nil && !f.VerifyUnchanged) {
+		return cf, err
+	}
+	rt := &worker.CopyInFile{Source: cf, VerifyUnchanged: f.VerifyUnchanged}
+	if f.Mode != nil {
+		rt.Mode = os.FileMode(*f.Mode) & os.ModePerm
+	}
+	return rt, nil
+}
+
+func convertCmdFileSource(f *CmdFile, srcPrefix string) (worker.CmdFile, error) {
 	switch {
 	case f == nil:
 		return nil, nil
@@ -293,8 +753,25 @@ func convertCmdFile(f *CmdFile, srcPrefix string) (worker.CmdFile, error) {
 		return &worker.MemoryFile{Content: []byte(*f.Content)}, nil
 	case f.FileID != nil:
 		return &worker.CachedFile{FileID: *f.FileID}, nil
+	case f.StageFile != nil:
+		return &worker.StageFile{Name: *f.StageFile}, nil
+	case f.Archive != nil:
+		src, err := convertCmdFile(f.Archive.File, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		format, err := envexec.StringToArchiveFormat(f.Archive.Format)
+		if err != nil {
+			return nil, err
+		}
+		return &worker.ArchiveFile{
+			Source:     src,
+			Format:     format,
+			Limit:      envexec.Size(f.Archive.SizeLimit),
+			InodeLimit: f.Archive.InodeLimit,
+		}, nil
 	case f.Max != nil && f.Name != nil:
-		return &worker.Collector{Name: *f.Name, Max: envexec.Size(*f.Max), Pipe: f.Pipe}, nil
+		return &worker.Collector{Name: *f.Name, Max: envexec.Size(*f.Max), Pipe: f.Pipe, Tail: envexec.Size(f.Tail)}, nil
 	default:
 		return nil, fmt.Errorf("file is not valid for cmd")
 	}
@@ -0,0 +1,46 @@
+package restexecutor
+
+import (
+	"net/http"
+
+	"github.com/criyle/go-judge/worker"
+	"github.com/gin-gonic/gin"
+)
+
+// parallelism defines the target and the current number of worker loops
+type parallelism struct {
+	Parallelism int `json:"parallelism"`
+	Current     int `json:"current"`
+}
+
+// adminOnly rejects requests not authenticated by the admin token
+func adminOnly(c *gin.Context) {
+	if !worker.IsAdmin(c.Request.Context()) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "admin token required")
+		return
+	}
+	c.Next()
+}
+
+func (h *handle) parallelismGet(c *gin.Context) {
+	target, current := h.worker.Parallelism()
+	c.JSON(http.StatusOK, parallelism{Parallelism: target, Current: current})
+}
+
+func (h *handle) parallelismPut(c *gin.Context) {
+	var req struct {
+		Parallelism *int `json:"parallelism"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Parallelism == nil || *req.Parallelism <= 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "parallelism must be positive")
+		return
+	}
+	h.worker.SetParallelism(*req.Parallelism)
+	h.logger.Sugar().Infof("parallelism set to %d", *req.Parallelism)
+	h.parallelismGet(c)
+}
@@ -13,17 +13,20 @@ import (
 
 // Register registers executor the handler
 //
-// POST /run, GET /file, POST /file, GET /file/:fid, DELETE /file/:fid
+// POST /run, GET /file, POST /file, GET /file/:fid, DELETE /file/:fid,
+// GET /admin/parallelism, PUT /admin/parallelism (only if admin is enabled)
 type Register interface {
 	Register(*gin.Engine)
 }
 
-// New creates new REST API handler
-func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, logger *zap.Logger) Register {
+// New creates new REST API handler, admin enables the admin handles which
+// requires the admin token
+func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, admin bool, logger *zap.Logger) Register {
 	return &handle{
 		worker:     worker,
 		fileHandle: fileHandle{fs: fs},
 		srcPrefix:  srcPrefix,
+		admin:      admin,
 		logger:     logger,
 	}
 }
@@ -32,6 +35,7 @@ type handle struct {
 	worker worker.Worker
 	fileHandle
 	srcPrefix string
+	admin     bool
 	logger    *zap.Logger
 }
 
@@ -44,6 +48,14 @@ func (h *handle) Register(r *gin.Engine) {
 	r.POST("/file", h.filePost)
 	r.GET("/file/:fid", h.fileIDGet)
 	r.DELETE("/file/:fid", h.fileIDDelete)
+
+	// Admin handle
+	if !h.admin {
+		return
+	}
+	admin := r.Group("/admin", adminOnly)
+	admin.GET("/parallelism", h.parallelismGet)
+	admin.PUT("/parallelism", h.parallelismPut)
 }
 
 func (h *handle) handleRun(c *gin.Context) {
@@ -54,7 +66,7 @@ func (h *handle) handleRun(c *gin.Context) {
 		return
 	}
 
-	if len(req.Cmd) == 0 {
+	if len(req.Cmd) == 0 && req.Batch == nil && req.Interactive == nil && len(req.Stages) == 0 {
 		c.AbortWithStatusJSON(http.StatusBadRequest, "no cmd provided")
 		return
 	}
@@ -70,7 +82,7 @@ func (h *handle) handleRun(c *gin.Context) {
 	h.logger.Sugar().Debugf("response: %+v", rt)
 	if rt.Error != nil {
 		c.Error(rt.Error)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, rt.Error.Error())
+		c.AbortWithStatusJSON(model.ErrorCode(rt.Error), rt.Error.Error())
 		return
 	}
 
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/criyle/go-judge/worker"
+	"gopkg.in/yaml.v2"
+)
+
+// Tenant defines the scheduling configuration for a tenant
+type Tenant struct {
+	Name        string `yaml:"name"`
+	Token       string `yaml:"token"`
+	Weight      int    `yaml:"weight"`
+	Parallelism int    `yaml:"parallelism"`
+}
+
+// Tenants defines the tenants sharing the executor server.
+// Default applies to the tenants not listed (including requests without tenant)
+type Tenants struct {
+	Default Tenant   `yaml:"default"`
+	Tenants []Tenant `yaml:"tenants"`
+}
+
+func readTenantConfig(p string) (*Tenants, error) {
+	var t Tenants
+	d, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(d, &t); err != nil {
+		return nil, err
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// validate rejects tenants without name and duplicated names or tokens, since
+// a token mapped to an empty or ambiguous tenant must not identify a caller
+func (t *Tenants) validate() error {
+	names := make(map[string]bool, len(t.Tenants))
+	tokens := make(map[string]bool, len(t.Tenants))
+	for i, tt := range t.Tenants {
+		if tt.Name == "" {
+			return fmt.Errorf("tenant %d: empty name", i)
+		}
+		if names[tt.Name] {
+			return fmt.Errorf("tenant %q: duplicated name", tt.Name)
+		}
+		names[tt.Name] = true
+		if tt.Token == "" {
+			continue
+		}
+		if tokens[tt.Token] {
+			return fmt.Errorf("tenant %q: duplicated token", tt.Name)
+		}
+		tokens[tt.Token] = true
+	}
+	return nil
+}
+
+func (t *Tenants) workerConfig() (map[string]worker.TenantConfig, worker.TenantConfig) {
+	m := make(map[string]worker.TenantConfig, len(t.Tenants))
+	for _, tt := range t.Tenants {
+		m[tt.Name] = worker.TenantConfig{
+			Weight:        tt.Weight,
+			Parallelism:   tt.Parallelism,
+			Authenticated: tt.Token != "",
+		}
+	}
+	return m, worker.TenantConfig{
+		Weight:      t.Default.Weight,
+		Parallelism: t.Default.Parallelism,
+	}
+}
+
+// authToken defines the identity of a bearer token
+type authToken struct {
+	tenant string
+	admin  bool
+}
+
+// authTokens returns the mapping from bearer token to its identity, token
+// is the admin token
+func (t *Tenants) authTokens(token string) (map[string]authToken, error) {
+	m := make(map[string]authToken)
+	if token != "" {
+		m[token] = authToken{admin: true}
+	}
+	for _, tt := range t.Tenants {
+		if tt.Token == "" {
+			continue
+		}
+		if _, ok := m[tt.Token]; ok {
+			return nil, fmt.Errorf("tenant %q: token conflicts with the admin token", tt.Name)
+		}
+		m[tt.Token] = authToken{tenant: tt.Name}
+	}
+	return m, nil
+}
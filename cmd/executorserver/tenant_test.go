@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/criyle/go-judge/worker"
+)
+
+func TestTenantsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenants []Tenant
+		ok      bool
+	}{
+		{"valid", []Tenant{{Name: "a", Token: "ta"}, {Name: "b"}, {Name: "c"}}, true},
+		{"empty name", []Tenant{{Name: "", Token: "t"}}, false},
+		{"duplicated name", []Tenant{{Name: "a"}, {Name: "a", Token: "t"}}, false},
+		{"duplicated token", []Tenant{{Name: "a", Token: "t"}, {Name: "b", Token: "t"}}, false},
+	}
+	for _, tc := range tests {
+		err := (&Tenants{Tenants: tc.tenants}).validate()
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: validate() = %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
+
+func TestAuthTokens(t *testing.T) {
+	ts := &Tenants{Tenants: []Tenant{{Name: "a", Token: "ta"}, {Name: "b"}}}
+	m, err := ts.authTokens("admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 2 {
+		t.Fatalf("tokens = %v, expected admin and ta", m)
+	}
+
+	ctx := withAuthToken(context.Background(), m["admin"])
+	if !worker.IsAdmin(ctx) {
+		t.Error("admin token is not marked as admin")
+	}
+	if _, ok := worker.TenantFromContext(ctx); ok {
+		t.Error("admin token carries a tenant")
+	}
+
+	ctx = withAuthToken(context.Background(), m["ta"])
+	if worker.IsAdmin(ctx) {
+		t.Error("tenant token is marked as admin")
+	}
+	if tenant, _ := worker.TenantFromContext(ctx); tenant != "a" {
+		t.Errorf("tenant = %q, expected a", tenant)
+	}
+
+	if _, err := ts.authTokens("ta"); err == nil {
+		t.Error("tenant token conflicting with admin token is accepted")
+	}
+	m, err = ts.authTokens("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tok, id := range m {
+		if id.admin {
+			t.Errorf("token %q is admin without admin token", tok)
+		}
+	}
+}
@@ -64,6 +64,8 @@ func (h *wsHandle) handleWS(c *gin.Context) {
 	}
 	resultCh := make(chan model.Response, 128)
 	cm := newContextMap()
+	// request context ends after upgrade, keeps the tenant from auth
+	tenant, _ := worker.TenantFromContext(c.Request.Context())
 
 	handleRequest := func(baseCtx context.Context, req *wsRequest) error {
 		if req.CancelRequestId != "" {
@@ -137,7 +139,7 @@ func (h *wsHandle) handleWS(c *gin.Context) {
 			return nil
 		})
 
-		baseCtx, baseCancel := context.WithCancel(context.TODO())
+		baseCtx, baseCancel := context.WithCancel(worker.WithTenant(context.TODO(), tenant))
 		defer baseCancel()
 
 		for {
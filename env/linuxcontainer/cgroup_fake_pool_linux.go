@@ -29,6 +29,11 @@ func (f *FakeCgroupPool) Put(c Cgroup) {
 	c.Destroy()
 }
 
+// Group creates a new parent cgroup
+func (f *FakeCgroupPool) Group() (CgroupGroup, error) {
+	return newCgroupGroup(f.builder, f.cfsPeriod)
+}
+
 // Shutdown noop
 func (f *FakeCgroupPool) Shutdown() {
 
@@ -0,0 +1,134 @@
+package linuxcontainer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-sandbox/pkg/cgroup"
+)
+
+var _ CgroupGroup = &wCgroupGroup{}
+
+// wCgroupGroup is the parent cgroup with the builder for its child cgroups
+type wCgroupGroup struct {
+	wCgroup
+	builder *cgroup.Builder
+}
+
+func newCgroupGroup(builder CgroupBuilder, cfsPeriod time.Duration) (CgroupGroup, error) {
+	b, ok := builder.(*cgroup.Builder)
+	if !ok {
+		return nil, fmt.Errorf("cgroup group: nesting is not supported by the builder")
+	}
+
+	var (
+		name string
+		cg   cgroup.Cgroup
+		err  error
+	)
+	for try := 0; ; try++ {
+		name = "group" + strconv.Itoa(int(rand.Int31()))
+		cg, err = b.Build(name)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) || try >= 10000 {
+			return nil, fmt.Errorf("cgroup group: %v", err)
+		}
+	}
+
+	// enable controllers for the child cgroups, v1 hierarchies do not need it
+	if v2, ok := cg.(*cgroup.CgroupV2); ok {
+		for _, c := range []struct {
+			name    string
+			enabled bool
+		}{
+			{"cpu", b.CPU},
+			{"cpuset", b.CPUSet},
+			{"memory", b.Memory},
+			{"pids", b.Pids},
+		} {
+			if c.enabled {
+				v2.WriteFile("cgroup.subtree_control", []byte("+"+c.name))
+			}
+		}
+	}
+
+	return &wCgroupGroup{
+		wCgroup: wCgroup{cg: cg, cfsPeriod: cfsPeriod},
+		builder: &cgroup.Builder{
+			Prefix:  path.Join(b.Prefix, name),
+			Type:    b.Type,
+			CPU:     b.CPU,
+			CPUSet:  b.CPUSet,
+			CPUAcct: b.CPUAcct,
+			Memory:  b.Memory,
+			Pids:    b.Pids,
+		},
+	}, nil
+}
+
+// Get creates a new child cgroup
+func (c *wCgroupGroup) Get() (Cgroup, error) {
+	cg, err := c.builder.Random("")
+	if err != nil {
+		return nil, err
+	}
+	return &wCgroup{cg: cg, cfsPeriod: c.cfsPeriod}, nil
+}
+
+// Put destroys the child cgroup
+func (c *wCgroupGroup) Put(cg Cgroup) {
+	cg.Destroy()
+}
+
+// MemoryUsage reads memory.peak for cgroup v2 if available since the
+// processes may have exited
+func (c *wCgroupGroup) MemoryUsage() (envexec.Size, error) {
+	if v2, ok := c.cg.(*cgroup.CgroupV2); ok {
+		if s, err := v2.ReadUint("memory.peak"); err == nil {
+			return envexec.Size(s), nil
+		}
+	}
+	return c.wCgroup.MemoryUsage()
+}
+
+// Exceeded reads memory.events and pids.events for cgroup v2, the limit is
+// not reported for cgroup v1
+func (c *wCgroupGroup) Exceeded() envexec.GroupLimitType {
+	v2, ok := c.cg.(*cgroup.CgroupV2)
+	if !ok {
+		return envexec.GroupLimitNone
+	}
+	if n, _ := readCgroupEvent(v2, "memory.events", "oom_kill"); n > 0 {
+		return envexec.GroupLimitMemory
+	}
+	if n, _ := readCgroupEvent(v2, "pids.events", "max"); n > 0 {
+		return envexec.GroupLimitProc
+	}
+	return envexec.GroupLimitNone
+}
+
+// readCgroupEvent reads the counter of the key from the flat keyed file
+func readCgroupEvent(cg *cgroup.CgroupV2, name, key string) (uint64, error) {
+	b, err := cg.ReadFile(name)
+	if err != nil {
+		return 0, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		f := bytes.Fields(s.Bytes())
+		if len(f) == 2 && string(f[0]) == key {
+			return strconv.ParseUint(string(f[1]), 10, 64)
+		}
+	}
+	return 0, os.ErrNotExist
+}
@@ -26,6 +26,23 @@ type Cgroup interface {
 type CgroupPool interface {
 	Get() (Cgroup, error)
 	Put(Cgroup)
+
+	// Group creates a new parent cgroup to limit the cgroups got from it
+	Group() (CgroupGroup, error)
+}
+
+// CgroupGroup defines the parent cgroup that limits and monitors the resources
+// consumption of the cgroups got from it in aggregate
+type CgroupGroup interface {
+	Cgroup
+
+	// Get creates a new child cgroup
+	Get() (Cgroup, error)
+	// Put destroys the child cgroup
+	Put(Cgroup)
+
+	// Exceeded reports the limit of the cgroup that was reached
+	Exceeded() envexec.GroupLimitType
 }
 
 // CgroupListPool implements cgroup pool
@@ -69,6 +86,11 @@ func (w *CgroupListPool) Put(c Cgroup) {
 	w.cgs = append(w.cgs, c)
 }
 
+// Group creates a new parent cgroup, the child cgroups are not pooled
+func (w *CgroupListPool) Group() (CgroupGroup, error) {
+	return newCgroupGroup(w.builder, w.cfsPeriod)
+}
+
 // Shutdown destroy all cgroup
 func (w *CgroupListPool) Shutdown() {
 	w.mu.Lock()
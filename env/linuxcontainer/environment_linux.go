@@ -15,7 +15,10 @@ import (
 	"github.com/criyle/go-sandbox/runner"
 )
 
-var _ envexec.Environment = &environ{}
+var (
+	_ envexec.Environment              = &environ{}
+	_ envexec.ResourceGroupEnvironment = &environ{}
+)
 
 // environ defines interface to access container resources
 type environ struct {
@@ -40,13 +43,20 @@ func (c *environ) Reset() error {
 func (c *environ) Execve(ctx context.Context, param envexec.ExecveParam) (envexec.Process, error) {
 	var (
 		cg       Cgroup
+		put      func(Cgroup)
 		syncFunc func(int) error
 		err      error
 	)
 
 	limit := param.Limit
 	if c.cgPool != nil {
-		cg, err = c.cgPool.Get()
+		get := c.cgPool.Get
+		put = c.cgPool.Put
+		// child cgroup of the resource group
+		if g, ok := param.Group.(*resourceGroup); ok {
+			get, put = g.cg.Get, g.cg.Put
+		}
+		cg, err = get()
 		if err != nil {
 			return nil, fmt.Errorf("execve: failed to get cgroup %v", err)
 		}
@@ -89,7 +99,7 @@ func (c *environ) Execve(ctx context.Context, param envexec.ExecveParam) (envexe
 	}
 	proc := newProcess(func() runner.Result {
 		return c.Environment.Execve(ctx, p)
-	}, cg, c.cgPool)
+	}, cg, put)
 
 	select {
 	case <-proc.done:
@@ -99,15 +109,62 @@ func (c *environ) Execve(ctx context.Context, param envexec.ExecveParam) (envexe
 	return proc, nil
 }
 
+// NewResourceGroup creates the parent cgroup to limit the processes in aggregate
+func (c *environ) NewResourceGroup(limit envexec.GroupLimit) (envexec.ResourceGroup, error) {
+	if c.cgPool == nil {
+		return nil, fmt.Errorf("cgroup is not enabled")
+	}
+	cg, err := c.cgPool.Group()
+	if err != nil {
+		return nil, err
+	}
+	if limit.Memory > 0 {
+		if err := cg.SetMemoryLimit(limit.Memory); isCgroupSetHasError(err) {
+			cg.Destroy()
+			return nil, fmt.Errorf("cgroup failed to set memory limit %v", err)
+		}
+	}
+	// the process limit is not checked by usage so that it must be enforced
+	if limit.Proc > 0 {
+		if err := cg.SetProcLimit(limit.Proc); err != nil {
+			cg.Destroy()
+			return nil, fmt.Errorf("cgroup failed to set process limit %v", err)
+		}
+	}
+	return &resourceGroup{cg: cg}, nil
+}
+
+// resourceGroup wraps the parent cgroup as envexec.ResourceGroup
+type resourceGroup struct {
+	cg CgroupGroup
+}
+
+func (r *resourceGroup) Usage() envexec.Usage {
+	t, _ := r.cg.CPUUsage()
+	m, _ := r.cg.MemoryUsage()
+	return envexec.Usage{
+		Time:   t,
+		Memory: m,
+	}
+}
+
+func (r *resourceGroup) Exceeded() envexec.GroupLimitType {
+	return r.cg.Exceeded()
+}
+
+func (r *resourceGroup) Destroy() error {
+	return r.cg.Destroy()
+}
+
 // WorkDir returns opened work directory, should not close after
 func (c *environ) WorkDir() *os.File {
 	c.wd.Seek(0, 0)
 	return c.wd
 }
 
-// Open opens file relative to work directory
+// Open opens file relative to work directory, symlinks are not followed
 func (c *environ) Open(path string, flags int, perm os.FileMode) (*os.File, error) {
-	fd, err := syscall.Openat(int(c.wd.Fd()), path, flags|syscall.O_CLOEXEC, uint32(perm))
+	fd, err := openAt(int(c.wd.Fd()), path, flags, uint32(perm))
 	if err != nil {
 		return nil, &os.PathError{Op: "open", Path: path, Err: err}
 	}
@@ -118,6 +175,14 @@ func (c *environ) Open(path string, flags int, perm os.FileMode) (*os.File, erro
 	return f, nil
 }
 
+// Mkdir creates directory relative to work directory, symlinks are not followed
+func (c *environ) Mkdir(path string, perm os.FileMode) error {
+	if err := mkdirAt(int(c.wd.Fd()), path, uint32(perm)); err != nil {
+		return &os.PathError{Op: "mkdir", Path: path, Err: err}
+	}
+	return nil
+}
+
 func (c *environ) setCgroupLimit(cg Cgroup, limit envexec.Limit) error {
 	cpuSet := limit.CPUSet
 	if cpuSet == "" {
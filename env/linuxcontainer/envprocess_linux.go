@@ -16,15 +16,16 @@ type process struct {
 	cg   Cgroup
 }
 
-func newProcess(run func() runner.Result, cg Cgroup, cgPool CgroupPool) *process {
+// newProcess runs the process and puts the cgroup after it exits
+func newProcess(run func() runner.Result, cg Cgroup, put func(Cgroup)) *process {
 	p := &process{
 		done: make(chan struct{}),
 		cg:   cg,
 	}
 	go func() {
 		defer close(p.done)
-		if cgPool != nil {
-			defer cgPool.Put(cg)
+		if put != nil {
+			defer put(cg)
 		}
 		p.rt = run()
 		p.collectUsage()
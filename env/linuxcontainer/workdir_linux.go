@@ -0,0 +1,81 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"syscall"
+)
+
+// openParentAt opens the parent directory of the path relative to the work dir
+// without following symlinks in any of its components, so that the program
+// running in the work dir cannot redirect the host side file operations out
+// of the work dir. It returns the fd of the parent and the last component,
+// the fd should be closed if it is not dirFd
+func openParentAt(dirFd int, p string) (int, string, error) {
+	if path.IsAbs(p) {
+		return -1, "", fmt.Errorf("path is absolute")
+	}
+	p = path.Clean(p)
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return -1, "", fmt.Errorf("path escapes the work dir")
+	}
+	parts := strings.Split(p, "/")
+	fd := dirFd
+	for _, n := range parts[:len(parts)-1] {
+		nfd, err := syscall.Openat(fd, n, syscall.O_CLOEXEC|syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_NOFOLLOW, 0)
+		if fd != dirFd {
+			syscall.Close(fd)
+		}
+		if err != nil {
+			return -1, "", err
+		}
+		fd = nfd
+	}
+	return fd, parts[len(parts)-1], nil
+}
+
+// openAt opens the path relative to the work dir without following symlinks,
+// fifo and devices are rejected to avoid blocking on open or read
+func openAt(dirFd int, p string, flags int, perm uint32) (int, error) {
+	pfd, name, err := openParentAt(dirFd, p)
+	if err != nil {
+		return -1, err
+	}
+	if pfd != dirFd {
+		defer syscall.Close(pfd)
+	}
+	fd, err := syscall.Openat(pfd, name, flags|syscall.O_CLOEXEC|syscall.O_NOFOLLOW|syscall.O_NONBLOCK, perm)
+	if err != nil {
+		return -1, err
+	}
+	var st syscall.Stat_t
+	if err := syscall.Fstat(fd, &st); err != nil {
+		syscall.Close(fd)
+		return -1, err
+	}
+	if t := st.Mode & syscall.S_IFMT; t != syscall.S_IFREG && t != syscall.S_IFDIR {
+		syscall.Close(fd)
+		return -1, fmt.Errorf("not a regular file")
+	}
+	if flags&syscall.O_NONBLOCK == 0 {
+		if err := syscall.SetNonblock(fd, false); err != nil {
+			syscall.Close(fd)
+			return -1, err
+		}
+	}
+	return fd, nil
+}
+
+// mkdirAt creates the directory relative to the work dir without following
+// symlinks in any of its components
+func mkdirAt(dirFd int, p string, perm uint32) error {
+	pfd, name, err := openParentAt(dirFd, p)
+	if err != nil {
+		return err
+	}
+	if pfd != dirFd {
+		defer syscall.Close(pfd)
+	}
+	return syscall.Mkdirat(pfd, name, perm)
+}
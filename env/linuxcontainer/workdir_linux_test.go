@@ -0,0 +1,79 @@
+package linuxcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOpenAtNoFollow(t *testing.T) {
+	wd, outside := t.TempDir(), t.TempDir()
+	host := filepath.Join(outside, "host")
+	if err := os.WriteFile(host, []byte("host"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for n, target := range map[string]string{"stdout": host, "dir": outside} {
+		if err := os.Symlink(target, filepath.Join(wd, n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := syscall.Mkfifo(filepath.Join(wd, "fifo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(wd, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := os.Open(wd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	dirFd := int(d.Fd())
+
+	for _, p := range []string{"stdout", "dir/host", "fifo", "/etc/passwd", "../x", "sub/../../x"} {
+		done := make(chan error, 1)
+		go func() {
+			fd, err := openAt(dirFd, p, syscall.O_CREAT|syscall.O_WRONLY|syscall.O_TRUNC, 0644)
+			if err == nil {
+				syscall.Close(fd)
+			}
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Errorf("%s: expected error", p)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: open blocked", p)
+		}
+	}
+	if err := mkdirAt(dirFd, "dir/new", 0755); err == nil {
+		t.Errorf("mkdir through symlink expected error")
+	}
+	if b, err := os.ReadFile(host); err != nil || string(b) != "host" {
+		t.Errorf("host file modified: %q %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "new")); err == nil {
+		t.Errorf("directory created outside of the work dir")
+	}
+
+	// regular paths
+	if err := mkdirAt(dirFd, "sub/a", 0755); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := openAt(dirFd, "sub/a/b", syscall.O_CREAT|syscall.O_WRONLY|syscall.O_TRUNC, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(fd)
+	if _, err := syscall.Write(fd, []byte("ok")); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(wd, "sub/a/b")); err != nil || string(b) != "ok" {
+		t.Errorf("unexpected content %q %v", b, err)
+	}
+}
@@ -275,6 +275,11 @@ func (e *Environment) Open(p string, flags int, perm os.FileMode) (*os.File, err
 	return os.OpenFile(path.Join(e.root, p), flags, perm)
 }
 
+// Mkdir creates directory related to root
+func (e *Environment) Mkdir(p string, perm os.FileMode) error {
+	return os.Mkdir(path.Join(e.root, p), perm)
+}
+
 // Destroy destroys the environment
 func (e *Environment) Destroy() error {
 	return e.wd.Close()
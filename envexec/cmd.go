@@ -22,6 +22,10 @@ type Cmd struct {
 	// file contents to copyin before exec
 	CopyIn map[string]File
 
+	// CopyInDigests verifies the files copied in by a previous run in the same
+	// environment unchanged after exec together with the CopyIn files
+	CopyInDigests []CopyInDigest
+
 	// exec argument, environment
 	Args []string
 	Env  []string
@@ -51,14 +55,40 @@ type Cmd struct {
 	CopyOut    []CmdCopyOutFile
 	CopyOutMax Size // file size limit
 
-	// CopyOutDir specifies a dir to dump all /w contnet
+	// CopyOutDir specifies a dir to dump all /w contnet recursively
 	CopyOutDir string
+	// CopyOutDirMax limits the total size of the dumped files if > 0
+	CopyOutDirMax Size
+}
+
+// CopyOutDirEntry defines a file or a directory visited by CopyOutDir.
+// Directories nested over 32 levels are skipped and the manifest is truncated
+// after 10000 entries with the last entry skipped as "entry limit exceeded"
+type CopyOutDirEntry struct {
+	Name    string // Name is the path relative to the work dir
+	Mode    os.FileMode
+	Size    Size
+	Skipped string // Skipped is the reason if the file is not dumped
 }
 
 // CmdCopyOutFile defines the file to be copy out after cmd execution
 type CmdCopyOutFile struct {
 	Name     string // Name is the file out to copyOut
 	Optional bool   // Optional ignores the file if not exists
+
+	// Archive packs the directory Name into an archive copied out as Name
+	Archive *CopyOutArchive
+}
+
+// CopyOutArchive defines how the directory is packed into the archive
+type CopyOutArchive struct {
+	Format ArchiveFormat
+	// Patterns selects the files by path.Match against their paths relative
+	// to the directory, all files are selected if empty
+	Patterns []string
+	// FileLimit limits the number of visited entries (including directories
+	// and files not selected) if > 0, or 10000 otherwise
+	FileLimit int
 }
 
 // Result defines the running result for single Cmd
@@ -78,6 +108,23 @@ type Result struct {
 
 	// FileError stores file errors details
 	FileError []FileError
+
+	// CopyOutDir is the manifest of the files visited by CopyOutDir
+	CopyOutDir []CopyOutDirEntry
+
+	// OutputSize is the number of bytes written by the program to each collector
+	OutputSize map[string]Size
+
+	// Killed indicates the Cmd was terminated because another Cmd in the group
+	// exited by KillOnExit or the group policy
+	Killed bool
+
+	// CopyInDigests are the digests of the files verified unchanged, which
+	// could be verified again by a later run in the same environment
+	CopyInDigests []CopyInDigest
+
+	// GroupLimitExceeded is the aggregate limit of the group that was exceeded
+	GroupLimitExceeded GroupLimitType
 }
 
 type FileErrorType int
@@ -92,6 +139,7 @@ const (
 	ErrCopyOutCreateFile
 	ErrCopyOutCopyContent
 	ErrCollectSizeExceeded
+	ErrCopyInModified
 )
 
 type FileError struct {
@@ -110,6 +158,7 @@ var fileErrorString = []string{
 	"CopyOutCreateFile",
 	"CopyOutCopyContent",
 	"CollectSizeExceeded",
+	"CopyInModified",
 }
 
 var fileErrorStringReverse = make(map[string]FileErrorType)
@@ -1,5 +1,10 @@
 package envexec
 
+import "time"
+
 const (
 	defaultExtraMemoryLimit = Size(16 << 10) // 16k more memory
 )
+
+// groupTickInterval defines the interval to check the group limits
+const groupTickInterval = 100 * time.Millisecond
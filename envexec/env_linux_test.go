@@ -0,0 +1,105 @@
+package envexec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/criyle/go-sandbox/runner"
+	"golang.org/x/sys/unix"
+)
+
+// testProgram runs in place of the process named by args[0] with the fds of
+// the process, it returns the exit status
+type testProgram func(ctx context.Context, args []string, files []*os.File) int
+
+// testRunEnv implements Environment inside a temporary directory and runs the
+// programs as goroutines
+type testRunEnv struct {
+	*testEnv
+	programs map[string]testProgram
+}
+
+func newTestRunEnv(t *testing.T, programs map[string]testProgram) *testRunEnv {
+	return &testRunEnv{testEnv: newTestEnv(t), programs: programs}
+}
+
+func (e *testRunEnv) Execve(ctx context.Context, param ExecveParam) (Process, error) {
+	prog, ok := e.programs[param.Args[0]]
+	if !ok {
+		return nil, errors.New("program not found")
+	}
+	// the fds are closed once Execve returns
+	files := make([]*os.File, 0, len(param.Files))
+	for _, fd := range param.Files {
+		if fd == ^uintptr(0) {
+			files = append(files, nil)
+			continue
+		}
+		nfd, err := unix.Dup(int(fd))
+		if err != nil {
+			closeFiles(files...)
+			return nil, err
+		}
+		files = append(files, os.NewFile(uintptr(nfd), ""))
+	}
+	p := &testRunProcess{done: make(chan struct{})}
+	start := time.Now()
+	go func() {
+		defer close(p.done)
+		status := prog(ctx, param.Args, files)
+		closeFiles(files...)
+		p.result = runner.Result{Status: runner.StatusNormal, ExitStatus: status, RunningTime: time.Since(start)}
+		if ctx.Err() != nil {
+			p.result.Status = runner.StatusSignalled
+			p.result.ExitStatus = int(unix.SIGKILL)
+		} else if status != 0 {
+			p.result.Status = runner.StatusNonzeroExitStatus
+		}
+	}()
+	return p, nil
+}
+
+type testRunProcess struct {
+	done   chan struct{}
+	result RunnerResult
+}
+
+func (p *testRunProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+func (p *testRunProcess) Result() RunnerResult {
+	<-p.done
+	return p.result
+}
+
+func (p *testRunProcess) Usage() Usage {
+	return Usage{}
+}
+
+// testRunCmd returns the cmd running the program until it exits or the
+// context is done
+func testRunCmd(env Environment, args ...string) *Cmd {
+	return &Cmd{
+		Environment: env,
+		Args:        args,
+		Waiter: func(ctx context.Context, p Process) bool {
+			select {
+			case <-ctx.Done():
+			case <-p.Done():
+			}
+			return false
+		},
+	}
+}
+
+// testStoreFile returns NewStoreFile creating files in a temporary directory
+func testStoreFile(t *testing.T) NewStoreFile {
+	dir := t.TempDir()
+	return func() (*os.File, error) {
+		return os.CreateTemp(dir, "")
+	}
+}
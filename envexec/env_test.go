@@ -0,0 +1,41 @@
+package envexec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testEnv implements Environment inside a temporary directory without Execve
+type testEnv struct {
+	dir string
+	wd  *os.File
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	dir := t.TempDir()
+	wd, err := os.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { wd.Close() })
+	return &testEnv{dir: dir, wd: wd}
+}
+
+func (e *testEnv) Execve(context.Context, ExecveParam) (Process, error) {
+	return nil, errors.New("not supported")
+}
+
+func (e *testEnv) WorkDir() *os.File {
+	return e.wd
+}
+
+func (e *testEnv) Open(p string, flags int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(filepath.Join(e.dir, p), flags, perm)
+}
+
+func (e *testEnv) Mkdir(p string, perm os.FileMode) error {
+	return os.Mkdir(filepath.Join(e.dir, p), perm)
+}
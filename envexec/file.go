@@ -44,11 +44,14 @@ func NewFileInput(p string) File {
 	return &FileInput{Path: p}
 }
 
-// FileCollector represent pipe output which will be collected through pipe
+// FileCollector represent pipe output which will be collected through pipe.
+// If Tail > 0, the last Tail bytes are also kept after a truncated marker
+// when the output exceeds the Limit
 type FileCollector struct {
 	Name  string
 	Limit Size
 	Pipe  bool
+	Tail  Size
 }
 
 func (*FileCollector) isFile() {}
@@ -83,6 +86,75 @@ func NewFileOpened(f *os.File) File {
 	return &FileOpened{File: f}
 }
 
+// FileCopyIn represent copyIn file created with the mode (0777 if 0), its
+// content is verified unchanged after exec if VerifyUnchanged
+type FileCopyIn struct {
+	File            File
+	Mode            os.FileMode
+	VerifyUnchanged bool
+}
+
+func (*FileCopyIn) isFile() {}
+
+// NewFileCopyIn creates copyIn file with the mode
+func NewFileCopyIn(f File, mode os.FileMode, verifyUnchanged bool) File {
+	return &FileCopyIn{File: f, Mode: mode, VerifyUnchanged: verifyUnchanged}
+}
+
+// ArchiveFormat defines the format of the archive file
+type ArchiveFormat int
+
+// Defines archive formats
+const (
+	ArchiveTar   ArchiveFormat = iota // tar
+	ArchiveTarGz                      // gzip compressed tar
+	ArchiveZip                        // zip
+)
+
+var archiveFormatString = []string{
+	"tar",
+	"tar.gz",
+	"zip",
+}
+
+func (f ArchiveFormat) String() string {
+	if f >= 0 && int(f) < len(archiveFormatString) {
+		return archiveFormatString[f]
+	}
+	return "unknown"
+}
+
+// StringToArchiveFormat converts the format name into ArchiveFormat
+func StringToArchiveFormat(s string) (ArchiveFormat, error) {
+	if s == "tgz" {
+		return ArchiveTarGz, nil
+	}
+	for i, n := range archiveFormatString {
+		if n == s {
+			return ArchiveFormat(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid archive format %q", s)
+}
+
+// FileArchive represent archive file which will be extracted into the
+// directory with the copyIn name. Only regular files and directories are
+// extracted, the total size and the number of the extracted files and
+// directories are limited if the limits are positive
+type FileArchive struct {
+	File       File
+	Format     ArchiveFormat
+	Limit      Size
+	InodeLimit int
+}
+
+func (*FileArchive) isFile() {}
+
+// NewFileArchive creates archive file which will be extracted in copyIn
+func NewFileArchive(f File, format ArchiveFormat, limit Size, inodeLimit int) File {
+	return &FileArchive{File: f, Format: format, Limit: limit, InodeLimit: inodeLimit}
+}
+
 // FileToReader get a ReadCloser from underlying file
 func FileToReader(f File) (io.ReadCloser, error) {
 	switch f := f.(type) {
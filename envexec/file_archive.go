@@ -0,0 +1,448 @@
+package envexec
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// archiveExtractor extracts archive entries into the directory of the work dir
+// and accounts the extracted size and inodes
+type archiveExtractor struct {
+	m   Environment
+	dir string
+
+	limit      Size
+	inodeLimit int
+	size       Size
+	inodes     int
+
+	dirs map[string]bool
+}
+
+// extractArchive extracts the archive read from r into the directory dir
+func extractArchive(m Environment, dir string, a *FileArchive, r io.Reader) error {
+	d, err := cleanArchivePath(dir)
+	if err != nil {
+		return err
+	}
+	e := &archiveExtractor{
+		m:          m,
+		dir:        d,
+		limit:      a.Limit,
+		inodeLimit: a.InodeLimit,
+		dirs:       make(map[string]bool),
+	}
+	if err := e.mkdirAll(d); err != nil {
+		return err
+	}
+	// the target directory is not accounted
+	e.inodes = 0
+
+	switch a.Format {
+	case ArchiveTar:
+		return e.tar(r)
+
+	case ArchiveTarGz:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		return e.tar(gr)
+
+	case ArchiveZip:
+		if fr, ok := a.File.(*FileReader); ok {
+			r = fr.Reader
+		}
+		ra, size, err := readerAt(r)
+		if err != nil {
+			return err
+		}
+		return e.zip(ra, size)
+
+	default:
+		return fmt.Errorf("archive format not supported %v", a.Format)
+	}
+}
+
+func (e *archiveExtractor) tar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			err = e.mkdir(h.Name)
+		case tar.TypeReg, tar.TypeRegA:
+			err = e.file(h.Name, h.FileInfo().Mode(), tr)
+		case tar.TypeXGlobalHeader:
+		default:
+			err = fmt.Errorf("archive entry %q: type %q is not supported", h.Name, h.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e *archiveExtractor) zip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			err = e.mkdir(f.Name)
+		case mode.IsRegular():
+			var rc io.ReadCloser
+			if rc, err = f.Open(); err == nil {
+				err = e.file(f.Name, mode, rc)
+				rc.Close()
+			}
+		default:
+			err = fmt.Errorf("archive entry %q: mode %v is not supported", f.Name, mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *archiveExtractor) mkdir(name string) error {
+	p, err := e.entryPath(name)
+	if err != nil {
+		return err
+	}
+	return e.mkdirAll(p)
+}
+
+func (e *archiveExtractor) file(name string, mode os.FileMode, r io.Reader) error {
+	p, err := e.entryPath(name)
+	if err != nil {
+		return err
+	}
+	if err := e.mkdirAll(path.Dir(p)); err != nil {
+		return err
+	}
+	if err := e.inode(name); err != nil {
+		return err
+	}
+
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = 0644
+	}
+	f, err := e.m.Open(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if e.limit > 0 {
+		r = io.LimitReader(r, int64(e.limit-e.size)+1)
+	}
+	n, err := f.ReadFrom(r)
+	e.size += Size(n)
+	if err != nil {
+		return err
+	}
+	if e.limit > 0 && e.size > e.limit {
+		return fmt.Errorf("archive entry %q: extracted size exceeds the limit %v", name, e.limit)
+	}
+	// keep the mode regardless of umask
+	return f.Chmod(perm)
+}
+
+// mkdirAll creates the directory and its parents that are not created yet
+func (e *archiveExtractor) mkdirAll(p string) error {
+	if p == "." || e.dirs[p] {
+		return nil
+	}
+	if err := e.mkdirAll(path.Dir(p)); err != nil {
+		return err
+	}
+	if err := e.inode(p); err != nil {
+		return err
+	}
+	if err := e.m.Mkdir(p, 0777); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	e.dirs[p] = true
+	return nil
+}
+
+func (e *archiveExtractor) inode(name string) error {
+	if e.inodeLimit > 0 && e.inodes >= e.inodeLimit {
+		return fmt.Errorf("archive entry %q: number of extracted files exceeds the limit %d", name, e.inodeLimit)
+	}
+	e.inodes++
+	return nil
+}
+
+// entryPath returns the path of the entry relative to the work dir
+func (e *archiveExtractor) entryPath(name string) (string, error) {
+	p, err := cleanArchivePath(name)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(e.dir, p), nil
+}
+
+// cleanArchivePath rejects absolute paths and paths escaping the directory
+func cleanArchivePath(name string) (string, error) {
+	if path.IsAbs(name) || strings.Contains(name, `\`) {
+		return "", fmt.Errorf("archive entry %q: invalid path", name)
+	}
+	p := path.Clean(name)
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("archive entry %q: path escapes the directory", name)
+	}
+	return p, nil
+}
+
+// readerAt returns the reader with random access and its size, the content is
+// read into memory if the reader does not support random access
+func readerAt(r io.Reader) (io.ReaderAt, int64, error) {
+	switch r := r.(type) {
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, fi.Size(), nil
+
+	case interface {
+		io.ReaderAt
+		Size() int64
+	}:
+		return r, r.Size(), nil
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(b), int64(len(b)), nil
+}
+
+// copyOutArchive packs the regular files in the directory into the archive,
+// symlinks and special files are skipped. The directory tree is walked by
+// the opened directories without following symlinks in any path component
+func copyOutArchive(m Environment, n CmdCopyOutFile, max Size, newStoreFile NewStoreFile) (*os.File, FileErrorType, error) {
+	name, err := cleanArchivePath(n.Name)
+	if err != nil {
+		return nil, ErrCopyOutOpen, err
+	}
+	dir, err := openAtNoFollow(m.WorkDir(), name, true)
+	if err != nil {
+		return nil, ErrCopyOutOpen, err
+	}
+	defer dir.Close()
+
+	buf, err := newStoreFile()
+	if err != nil {
+		return nil, ErrCopyOutCreateFile, fmt.Errorf("%s: failed to create store file %v", n.Name, err)
+	}
+	aw, err := newArchiveWriter(buf, n.Archive.Format)
+	if err != nil {
+		buf.Close()
+		return nil, ErrCopyOutCreateFile, err
+	}
+	p := &archivePacker{w: aw, name: n.Name, archive: n.Archive, max: max}
+	t, err := p.walk(dir, "")
+	if err == nil {
+		if err = aw.Close(); err != nil {
+			t = ErrCopyOutCopyContent
+		}
+	}
+	if err != nil {
+		buf.Close()
+		return nil, t, err
+	}
+	return buf, 0, nil
+}
+
+// archivePacker writes the regular files selected by the patterns into the
+// archive, the total size is limited by max. The number of visited entries
+// (including the ones not selected) is limited by the file limit of the
+// archive if > 0 or copyOutDirMaxEntries, and the nesting level of the
+// directories is limited by copyOutDirMaxDepth as copyOutDir does
+type archivePacker struct {
+	w       archiveWriter
+	name    string
+	archive *CopyOutArchive
+	max     Size
+
+	size    int64
+	entries int
+	depth   int
+}
+
+func (p *archivePacker) entryLimit() int {
+	if p.archive.FileLimit > 0 {
+		return p.archive.FileLimit
+	}
+	return copyOutDirMaxEntries
+}
+
+func (p *archivePacker) walk(dir *os.File, rel string) (FileErrorType, error) {
+	// read one more entry than the remaining to detect the limit exceeded
+	entries, err := dir.ReadDir(p.entryLimit() - p.entries + 1)
+	if err != nil && err != io.EOF {
+		return ErrCopyOutOpen, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	for _, e := range entries {
+		name := path.Join(rel, e.Name())
+		p.entries++
+		if p.entries > p.entryLimit() {
+			return ErrCopyOutSizeExceeded, fmt.Errorf("%s: number of entries exceeded the limit (%d)", p.name, p.entryLimit())
+		}
+		switch {
+		case e.IsDir():
+			if p.depth >= copyOutDirMaxDepth {
+				return ErrCopyOutSizeExceeded, fmt.Errorf("%s: directory nested over the limit (%d)", path.Join(p.name, name), copyOutDirMaxDepth)
+			}
+			sub, err := openAtNoFollow(dir, e.Name(), true)
+			if err != nil {
+				return ErrCopyOutOpen, err
+			}
+			p.depth++
+			t, err := p.walk(sub, name)
+			p.depth--
+			sub.Close()
+			if err != nil {
+				return t, err
+			}
+
+		case e.Type().IsRegular():
+			if !matchArchivePatterns(p.archive.Patterns, name) {
+				continue
+			}
+			if t, err := p.file(dir, e.Name(), name); err != nil {
+				return t, err
+			}
+		}
+	}
+	return 0, nil
+}
+
+func (p *archivePacker) file(dir *os.File, n, name string) (FileErrorType, error) {
+	f, err := openAtNoFollow(dir, n, false)
+	if err != nil {
+		return ErrCopyOutOpen, err
+	}
+	defer f.Close()
+
+	// the entry may be replaced after it was listed
+	fi, err := f.Stat()
+	if err != nil {
+		return ErrCopyOutOpen, err
+	}
+	if !fi.Mode().IsRegular() {
+		return ErrCopyOutNotRegularFile, fmt.Errorf("%s: not a regular file: %v", path.Join(p.name, name), fi.Mode())
+	}
+	p.size += fi.Size()
+	if p.max > 0 && p.size > int64(p.max) {
+		return ErrCopyOutSizeExceeded, fmt.Errorf("%s: size (%d) exceeded the limit (%d)", p.name, p.size, p.max)
+	}
+	fw, err := p.w.create(name, fi)
+	if err != nil {
+		return ErrCopyOutCopyContent, err
+	}
+	// Ensure not copy over file size
+	if _, err := io.CopyN(fw, f, fi.Size()); err != nil {
+		return ErrCopyOutCopyContent, err
+	}
+	return 0, nil
+}
+
+func matchArchivePatterns(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveWriter writes regular files into the archive
+type archiveWriter interface {
+	create(name string, fi os.FileInfo) (io.Writer, error)
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	*tar.Writer
+	gz *gzip.Writer
+}
+
+func (w *tarArchiveWriter) create(name string, fi os.FileInfo) (io.Writer, error) {
+	err := w.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(fi.Mode().Perm()),
+		Size:     fi.Size(),
+		ModTime:  fi.ModTime(),
+	})
+	return w.Writer, err
+}
+
+func (w *tarArchiveWriter) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		return err
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return nil
+}
+
+type zipArchiveWriter struct {
+	*zip.Writer
+}
+
+func (w *zipArchiveWriter) create(name string, fi os.FileInfo) (io.Writer, error) {
+	h, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return nil, err
+	}
+	h.Name = name
+	h.Method = zip.Deflate
+	return w.CreateHeader(h)
+}
+
+func newArchiveWriter(w io.Writer, format ArchiveFormat) (archiveWriter, error) {
+	switch format {
+	case ArchiveTar:
+		return &tarArchiveWriter{Writer: tar.NewWriter(w)}, nil
+	case ArchiveTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarArchiveWriter{Writer: tar.NewWriter(gz), gz: gz}, nil
+	case ArchiveZip:
+		return &zipArchiveWriter{Writer: zip.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("archive format not supported %v", format)
+	}
+}
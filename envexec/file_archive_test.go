@@ -0,0 +1,316 @@
+package envexec
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestStoreFile(t *testing.T) NewStoreFile {
+	return func() (*os.File, error) {
+		return os.CreateTemp(t.TempDir(), "")
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for n, c := range files {
+		p := filepath.Join(dir, n)
+		if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readTestTar(t *testing.T, f *os.File) map[string]string {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	ret := make(map[string]string)
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return ret
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret[h.Name] = string(b)
+	}
+}
+
+func TestCopyOutArchive(t *testing.T) {
+	env := newTestEnv(t)
+	writeTestFiles(t, env.dir, map[string]string{
+		"out/a.txt":     "a",
+		"out/sub/b.txt": "b",
+		"out/c.log":     "c",
+	})
+
+	tests := []struct {
+		name     string
+		archive  CopyOutArchive
+		max      Size
+		expected map[string]string
+		errType  FileErrorType
+	}{
+		{
+			name:     "all",
+			expected: map[string]string{"a.txt": "a", "c.log": "c", "sub/b.txt": "b"},
+		},
+		{
+			name:     "patterns",
+			archive:  CopyOutArchive{Patterns: []string{"*.txt", "sub/*"}},
+			expected: map[string]string{"a.txt": "a", "sub/b.txt": "b"},
+		},
+		{
+			name:    "file limit",
+			archive: CopyOutArchive{FileLimit: 2},
+			errType: ErrCopyOutSizeExceeded,
+		},
+		{
+			name:    "unselected entries counted",
+			archive: CopyOutArchive{Patterns: []string{"sub/*"}, FileLimit: 3},
+			errType: ErrCopyOutSizeExceeded,
+		},
+		{
+			name:     "entries within limit",
+			archive:  CopyOutArchive{Patterns: []string{"sub/*"}, FileLimit: 4},
+			expected: map[string]string{"sub/b.txt": "b"},
+		},
+		{
+			name:    "size limit",
+			max:     2,
+			errType: ErrCopyOutSizeExceeded,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.archive
+			f, errType, err := copyOutArchive(env, CmdCopyOutFile{Name: "out", Archive: &a}, tc.max, newTestStoreFile(t))
+			if tc.expected == nil {
+				if err == nil || errType != tc.errType {
+					t.Fatalf("expected error type %v, got %v: %v", tc.errType, errType, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if got := readTestTar(t, f); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("packed %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCopyOutArchiveSymlink(t *testing.T) {
+	env := newTestEnv(t)
+	outside := t.TempDir()
+	writeTestFiles(t, outside, map[string]string{"secret": "secret"})
+	writeTestFiles(t, env.dir, map[string]string{"out/a.txt": "a"})
+	for n, target := range map[string]string{
+		"root":        outside,
+		"out/dir":     outside,
+		"out/file":    filepath.Join(outside, "secret"),
+		"parent/link": outside,
+	} {
+		p := filepath.Join(env.dir, n)
+		if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// symlinks inside the directory are skipped
+	f, _, err := copyOutArchive(env, CmdCopyOutFile{Name: "out", Archive: &CopyOutArchive{}}, 0, newTestStoreFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got, expected := readTestTar(t, f), map[string]string{"a.txt": "a"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("packed %v, expected %v", got, expected)
+	}
+
+	// symlinks in the directory path are not followed
+	for _, n := range []string{"root", "root/", "parent/link", "out/dir", "out/../root", "../" + filepath.Base(env.dir) + "/root"} {
+		if f, _, err := copyOutArchive(env, CmdCopyOutFile{Name: n, Archive: &CopyOutArchive{}}, 0, newTestStoreFile(t)); err == nil {
+			f.Close()
+			t.Errorf("%s: expected error for symlink escape", n)
+		}
+	}
+}
+
+func TestCopyOutArchiveDepthLimit(t *testing.T) {
+	env := newTestEnv(t)
+	parts := []string{"out"}
+	for i := 0; i < copyOutDirMaxDepth; i++ {
+		parts = append(parts, "d")
+	}
+	writeTestFiles(t, env.dir, map[string]string{filepath.Join(parts...) + "/a.txt": "a"})
+	f, _, err := copyOutArchive(env, CmdCopyOutFile{Name: "out", Archive: &CopyOutArchive{}}, 0, newTestStoreFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	writeTestFiles(t, env.dir, map[string]string{filepath.Join(parts...) + "/d/a.txt": "a"})
+	_, errType, err := copyOutArchive(env, CmdCopyOutFile{Name: "out", Archive: &CopyOutArchive{}}, 0, newTestStoreFile(t))
+	if err == nil || errType != ErrCopyOutSizeExceeded {
+		t.Errorf("expected error type %v, got %v: %v", ErrCopyOutSizeExceeded, errType, err)
+	}
+}
+
+func TestCopyOutArchiveNotExist(t *testing.T) {
+	env := newTestEnv(t)
+	_, _, err := copyOutArchive(env, CmdCopyOutFile{Name: "out", Archive: &CopyOutArchive{}}, 0, newTestStoreFile(t))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+type testTarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func newTestTar(t *testing.T, entries []testTarEntry) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		h := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag != tar.TypeReg {
+			h.Size = 0
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Size > 0 {
+			if _, err := tw.Write([]byte(e.content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractArchive(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []testTarEntry
+		limit    Size
+		inodes   int
+		expected map[string]string
+	}{
+		{
+			name: "files",
+			entries: []testTarEntry{
+				{name: "a.txt", typeflag: tar.TypeReg, content: "a"},
+				{name: "sub/", typeflag: tar.TypeDir},
+				{name: "sub/b.txt", typeflag: tar.TypeReg, content: "b"},
+				{name: "c/d.txt", typeflag: tar.TypeReg, content: "d"},
+			},
+			expected: map[string]string{"a.txt": "a", "sub/b.txt": "b", "c/d.txt": "d"},
+		},
+		{
+			name:    "parent",
+			entries: []testTarEntry{{name: "../escape", typeflag: tar.TypeReg, content: "x"}},
+		},
+		{
+			name:    "nested parent",
+			entries: []testTarEntry{{name: "sub/../../escape", typeflag: tar.TypeReg, content: "x"}},
+		},
+		{
+			name:    "absolute",
+			entries: []testTarEntry{{name: "/escape", typeflag: tar.TypeReg, content: "x"}},
+		},
+		{
+			name:    "backslash",
+			entries: []testTarEntry{{name: `..\escape`, typeflag: tar.TypeReg, content: "x"}},
+		},
+		{
+			name: "symlink",
+			entries: []testTarEntry{
+				{name: "link", typeflag: tar.TypeSymlink, linkname: ".."},
+				{name: "link/escape", typeflag: tar.TypeReg, content: "x"},
+			},
+		},
+		{
+			name:    "hard link",
+			entries: []testTarEntry{{name: "link", typeflag: tar.TypeLink, linkname: "/etc/passwd"}},
+		},
+		{
+			name:    "size limit",
+			entries: []testTarEntry{{name: "a.txt", typeflag: tar.TypeReg, content: "abc"}},
+			limit:   2,
+		},
+		{
+			name: "inode limit",
+			entries: []testTarEntry{
+				{name: "a.txt", typeflag: tar.TypeReg, content: "a"},
+				{name: "sub/b.txt", typeflag: tar.TypeReg, content: "b"},
+			},
+			inodes: 2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			env := &testEnv{dir: filepath.Join(root, "w")}
+			if err := os.Mkdir(env.dir, 0777); err != nil {
+				t.Fatal(err)
+			}
+			a := &FileArchive{Format: ArchiveTar, Limit: tc.limit, InodeLimit: tc.inodes}
+			err := extractArchive(env, "dir", a, bytes.NewReader(newTestTar(t, tc.entries)))
+			if tc.expected == nil {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				for _, p := range []string{root, env.dir} {
+					if _, err := os.Lstat(filepath.Join(p, "escape")); err == nil {
+						t.Fatal("entry extracted outside of the directory")
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for n, c := range tc.expected {
+				b, err := os.ReadFile(filepath.Join(env.dir, "dir", n))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != c {
+					t.Errorf("%s: extracted %q, expected %q", n, b, c)
+				}
+			}
+		})
+	}
+}
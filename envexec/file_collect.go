@@ -11,19 +11,34 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// collectResult defines the files read from the container
+type collectResult struct {
+	files      map[string]*os.File
+	fileError  []FileError
+	outputSize map[string]Size
+	manifest   []CopyOutDirEntry
+}
+
 // copyOutAndCollect reads file and pipes in parallel from container
-func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile NewStoreFile) (map[string]*os.File, []FileError, error) {
+func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile NewStoreFile) (collectResult, error) {
 	var (
 		g         errgroup.Group
 		l, le     sync.Mutex
 		fileError []FileError
+		manifest  []CopyOutDirEntry
 	)
 	rt := make(map[string]*os.File)
+	outputSize := make(map[string]Size)
 	put := func(f *os.File, n string) {
 		l.Lock()
 		defer l.Unlock()
 		rt[n] = f
 	}
+	putSize := func(n string, s Size) {
+		l.Lock()
+		defer l.Unlock()
+		outputSize[n] = s
+	}
 	addError := func(e FileError) {
 		le.Lock()
 		defer le.Unlock()
@@ -45,6 +60,19 @@ func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile
 				}
 			}()
 
+			if n.Archive != nil {
+				var buf *os.File
+				buf, t, err = copyOutArchive(m, n, c.CopyOutMax, newStoreFile)
+				if err != nil {
+					if errors.Is(err, os.ErrNotExist) && n.Optional {
+						return nil
+					}
+					return err
+				}
+				put(buf, n.Name)
+				return nil
+			}
+
 			cf, err := m.Open(n.Name, os.O_RDONLY, 0777)
 			if err != nil {
 				if errors.Is(err, os.ErrNotExist) && n.Optional {
@@ -94,7 +122,9 @@ func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile
 		g.Go(func() error {
 			<-p.done
 			put(p.buffer, p.name)
-			if fi, err := p.buffer.Stat(); err == nil && fi.Size() > int64(p.limit) {
+			s := p.total()
+			putSize(p.name, s)
+			if s > p.limit {
 				addError(FileError{
 					Name: p.name,
 					Type: ErrCollectSizeExceeded,
@@ -154,16 +184,24 @@ func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile
 			}
 
 			// Ensure not copy over file size
-			_, err = buf.ReadFrom(io.LimitReader(cf, int64(t.Limit)+1))
+			s := stat.Size()
+			if t.Tail > 0 && s > int64(t.Limit) {
+				_, err = buf.ReadFrom(io.NewSectionReader(cf, 0, int64(t.Limit)))
+				if err == nil {
+					err = copyTail(buf, cf, int64(t.Limit), s, int64(t.Tail))
+				}
+			} else {
+				_, err = buf.ReadFrom(io.LimitReader(cf, int64(t.Limit)+1))
+			}
 			if err != nil {
 				errType = ErrCopyOutCopyContent
 				buf.Close()
 				return err
 			}
 			put(buf, t.Name)
+			putSize(t.Name, Size(s))
 
 			// check size limit
-			s := stat.Size()
 			if s > int64(t.Limit) {
 				errType = ErrCollectSizeExceeded
 				return runner.StatusOutputLimitExceeded
@@ -174,11 +212,17 @@ func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector, newStoreFile
 
 	// copy out dir
 	if c.CopyOutDir != "" {
-		g.Go(func() error {
-			return copyDir(m.WorkDir(), c.CopyOutDir)
+		g.Go(func() (err error) {
+			manifest, err = copyDir(m.WorkDir(), c.CopyOutDir, c.CopyOutDirMax)
+			return err
 		})
 	}
 
 	err := g.Wait()
-	return rt, fileError, err
+	return collectResult{
+		files:      rt,
+		fileError:  fileError,
+		outputSize: outputSize,
+		manifest:   manifest,
+	}, err
 }
@@ -0,0 +1,84 @@
+package envexec
+
+import (
+	"os"
+)
+
+const (
+	copyOutDirSizeExceeded    = "size limit exceeded"
+	copyOutDirDepthExceeded   = "depth limit exceeded"
+	copyOutDirEntriesExceeded = "entry limit exceeded"
+
+	// copyOutDirMaxDepth limits the nesting level of the visited directories
+	copyOutDirMaxDepth = 32
+	// copyOutDirMaxEntries limits the number of the visited entries
+	copyOutDirMaxEntries = 10000
+)
+
+// dirCopier records the manifest and accounts the total size of the files
+// dumped by CopyOutDir
+type dirCopier struct {
+	max       Size
+	size      Size
+	depth     int
+	truncated bool
+	manifest  []CopyOutDirEntry
+}
+
+func (d *dirCopier) add(name string, mode os.FileMode, size Size) int {
+	d.manifest = append(d.manifest, CopyOutDirEntry{Name: name, Mode: mode, Size: size})
+	return len(d.manifest) - 1
+}
+
+func (d *dirCopier) skip(name string, mode os.FileMode, size Size, reason string) {
+	d.manifest = append(d.manifest, CopyOutDirEntry{Name: name, Mode: mode, Size: size, Skipped: reason})
+}
+
+// remain returns the number of entries that can still be visited
+func (d *dirCopier) remain() int {
+	return copyOutDirMaxEntries - len(d.manifest)
+}
+
+// visit returns false once the entry limit is reached, the first entry over
+// the limit is recorded as skipped to mark the manifest truncated
+func (d *dirCopier) visit(name string) bool {
+	if d.truncated {
+		return false
+	}
+	if d.remain() > 0 {
+		return true
+	}
+	d.truncated = true
+	d.skip(name, 0, 0, copyOutDirEntriesExceeded)
+	return false
+}
+
+// enter returns false if the directory is nested too deep to be copied
+func (d *dirCopier) enter() bool {
+	if d.depth >= copyOutDirMaxDepth {
+		return false
+	}
+	d.depth++
+	return true
+}
+
+func (d *dirCopier) leave() {
+	d.depth--
+}
+
+// reserve accounts the file size and returns false if it exceeds the limit
+func (d *dirCopier) reserve(size Size) bool {
+	if d.max > 0 && d.size+size > d.max {
+		return false
+	}
+	d.size += size
+	return true
+}
+
+// skipReason returns the reason for files that are neither regular files nor directories
+func skipReason(mode os.FileMode) string {
+	if mode&os.ModeSymlink != 0 {
+		return "symlink"
+	}
+	return "not a regular file"
+}
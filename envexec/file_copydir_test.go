@@ -0,0 +1,89 @@
+package envexec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyDirDepthLimit(t *testing.T) {
+	src := t.TempDir()
+	parts := make([]string, copyOutDirMaxDepth+2)
+	for i := range parts {
+		parts[i] = "d"
+	}
+	if err := os.MkdirAll(filepath.Join(src, filepath.Join(parts...)), 0777); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := os.Open(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dir.Close()
+
+	dst := t.TempDir()
+	manifest, err := copyDir(dir, dst, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) != copyOutDirMaxDepth+1 {
+		t.Fatalf("expected %d entries, got %d", copyOutDirMaxDepth+1, len(manifest))
+	}
+	for i, e := range manifest[:copyOutDirMaxDepth] {
+		if e.Skipped != "" {
+			t.Errorf("entry %d %s skipped: %s", i, e.Name, e.Skipped)
+		}
+	}
+	last := manifest[copyOutDirMaxDepth]
+	if last.Skipped != copyOutDirDepthExceeded {
+		t.Errorf("expected %s skipped by depth, got %q", last.Name, last.Skipped)
+	}
+	if _, err := os.Stat(filepath.Join(dst, filepath.Join(parts[:copyOutDirMaxDepth]...))); err != nil {
+		t.Errorf("expected deepest copied dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, filepath.Join(parts[:copyOutDirMaxDepth+1]...))); !os.IsNotExist(err) {
+		t.Errorf("expected dir over depth limit not copied, got %v", err)
+	}
+}
+
+func TestCopyDirEntryLimit(t *testing.T) {
+	src := t.TempDir()
+	// nest half of the files to count entries across directories
+	if err := os.Mkdir(filepath.Join(src, "sub"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < copyOutDirMaxEntries+10; i++ {
+		p := filepath.Join(src, fmt.Sprintf("f%05d", i))
+		if i%2 == 0 {
+			p = filepath.Join(src, "sub", fmt.Sprintf("f%05d", i))
+		}
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir, err := os.Open(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dir.Close()
+
+	manifest, err := copyDir(dir, t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) != copyOutDirMaxEntries+1 {
+		t.Fatalf("expected %d entries, got %d", copyOutDirMaxEntries+1, len(manifest))
+	}
+	var truncated int
+	for _, e := range manifest {
+		if e.Skipped == copyOutDirEntriesExceeded {
+			truncated++
+		} else if e.Skipped != "" {
+			t.Errorf("entry %s skipped: %s", e.Name, e.Skipped)
+		}
+	}
+	if truncated != 1 || manifest[len(manifest)-1].Skipped != copyOutDirEntriesExceeded {
+		t.Errorf("expected the last entry to mark the truncation, got %d marks", truncated)
+	}
+}
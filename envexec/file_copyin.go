@@ -1,18 +1,32 @@
 package envexec
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"os"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
 )
 
-// copyIn copied file from host to container in parallel
-func copyIn(m Environment, copyIn map[string]File) ([]FileError, error) {
+// CopyInDigest records the content of the copyIn file to be verified unchanged
+type CopyInDigest struct {
+	name string
+	size int64
+	sum  []byte
+}
+
+// copyIn copied file from host to container in parallel, it returns the
+// digests of the files to be verified unchanged after exec
+func copyIn(m Environment, copyIn map[string]File) ([]CopyInDigest, []FileError, error) {
 	var (
 		g         errgroup.Group
 		fileError []FileError
+		digests   []CopyInDigest
 		l         sync.Mutex
 	)
 	addError := func(e FileError) {
@@ -20,6 +34,11 @@ func copyIn(m Environment, copyIn map[string]File) ([]FileError, error) {
 		defer l.Unlock()
 		fileError = append(fileError, e)
 	}
+	addDigest := func(d CopyInDigest) {
+		l.Lock()
+		defer l.Unlock()
+		digests = append(digests, d)
+	}
 	for n, f := range copyIn {
 		n, f := n, f
 		g.Go(func() (err error) {
@@ -34,26 +53,114 @@ func copyIn(m Environment, copyIn map[string]File) ([]FileError, error) {
 				}
 			}()
 
+			var (
+				mode   os.FileMode = 0777
+				verify bool
+			)
+			if c, ok := f.(*FileCopyIn); ok {
+				f, verify = c.File, c.VerifyUnchanged
+				if c.Mode != 0 {
+					mode = c.Mode.Perm()
+				}
+			}
+			a, isArchive := f.(*FileArchive)
+			if isArchive {
+				f = a.File
+			}
 			hf, err := FileToReader(f)
 			if err != nil {
 				return fmt.Errorf("failed to copyIn %v", err)
 			}
 			defer hf.Close()
 
-			cf, err := m.Open(n, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0777)
+			if isArchive {
+				t = ErrCopyInCopyContent
+				return extractArchive(m, n, a, hf)
+			}
+
+			cf, err := m.Open(n, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
 			if err != nil {
 				t = ErrCopyInCreateFile
 				return err
 			}
 			defer cf.Close()
 
-			_, err = cf.ReadFrom(hf)
+			var (
+				r io.Reader = hf
+				h hash.Hash
+			)
+			if verify {
+				h = sha256.New()
+				r = io.TeeReader(hf, h)
+			}
+			size, err := cf.ReadFrom(r)
 			if err != nil {
 				t = ErrCopyInCopyContent
 				return err
 			}
+			if mode != 0777 {
+				// keep the mode regardless of umask
+				if err := cf.Chmod(mode); err != nil {
+					t = ErrCopyInCreateFile
+					return err
+				}
+			}
+			if verify {
+				addDigest(CopyInDigest{name: n, size: size, sum: h.Sum(nil)})
+			}
 			return nil
 		})
 	}
-	return fileError, g.Wait()
+	err := g.Wait()
+	return digests, fileError, err
+}
+
+// verifyCopyIn reports the copyIn files that were modified or deleted after exec
+func verifyCopyIn(m Environment, digests []CopyInDigest) ([]FileError, error) {
+	var (
+		fileError []FileError
+		err       error
+	)
+	for _, d := range digests {
+		msg := verifyCopyInFile(m, d)
+		if msg == "" {
+			continue
+		}
+		fileError = append(fileError, FileError{
+			Name:    d.name,
+			Type:    ErrCopyInModified,
+			Message: msg,
+		})
+		if err == nil {
+			err = fmt.Errorf("%s: copyIn file %s", d.name, msg)
+		}
+	}
+	return fileError, err
+}
+
+func verifyCopyInFile(m Environment, d CopyInDigest) string {
+	f, err := m.Open(d.name, os.O_RDONLY, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return "deleted"
+	}
+	if err != nil {
+		return err.Error()
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err.Error()
+	}
+	if !fi.Mode().IsRegular() || fi.Size() != d.size {
+		return "modified"
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err.Error()
+	}
+	if !bytes.Equal(h.Sum(nil), d.sum) {
+		return "modified"
+	}
+	return ""
 }
@@ -0,0 +1,44 @@
+package envexec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyInVerifyUnchanged(t *testing.T) {
+	env := newTestEnv(t)
+	digests, _, err := copyIn(env, map[string]File{
+		"a": NewFileCopyIn(NewFileReader(bytes.NewReader([]byte("binary")), false), 0555, true),
+		"b": NewFileReader(bytes.NewReader([]byte("not verified")), false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(digests) != 1 {
+		t.Fatalf("expected 1 digest, got %d", len(digests))
+	}
+	if fe, err := verifyCopyIn(env, digests); err != nil || len(fe) != 0 {
+		t.Fatalf("unexpected error %v %v", fe, err)
+	}
+
+	// the digests are verified again by the later runs
+	if err := os.Chmod(filepath.Join(env.dir, "a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(env.dir, "a"), []byte("modifid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fe, err := verifyCopyIn(env, digests)
+	if err == nil || len(fe) != 1 || fe[0].Type != ErrCopyInModified || fe[0].Message != "modified" {
+		t.Fatalf("expected modified, got %v %v", fe, err)
+	}
+	if err := os.Remove(filepath.Join(env.dir, "a")); err != nil {
+		t.Fatal(err)
+	}
+	fe, _ = verifyCopyIn(env, digests)
+	if len(fe) != 1 || fe[0].Message != "deleted" {
+		t.Fatalf("expected deleted, got %v", fe)
+	}
+}
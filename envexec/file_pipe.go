@@ -1,8 +1,10 @@
 package envexec
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 )
 
 type pipeBuffer struct {
@@ -10,6 +12,7 @@ type pipeBuffer struct {
 	Buffer *os.File
 	Done   <-chan struct{}
 	Limit  Size
+	Size   *int64 // Size is the number of bytes written into the pipe (atomic)
 }
 
 type pipeCollector struct {
@@ -17,6 +20,58 @@ type pipeCollector struct {
 	buffer *os.File
 	limit  Size
 	name   string
+	size   *int64 // size is the number of bytes written by the program if not nil (atomic)
+}
+
+// total returns the number of bytes written by the program
+func (p *pipeCollector) total() Size {
+	if p.size != nil {
+		return Size(atomic.LoadInt64(p.size))
+	}
+	if fi, err := p.buffer.Stat(); err == nil {
+		return Size(fi.Size())
+	}
+	return 0
+}
+
+// countReader counts the bytes read
+type countReader struct {
+	io.Reader
+	n *int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	atomic.AddInt64(r.n, int64(n))
+	return n, err
+}
+
+// fanOutWriter writes the data to all the output ends in order, the output end
+// is closed and dropped once the write failed (e.g. the reader closed it)
+type fanOutWriter struct {
+	ws []*os.File
+}
+
+func (f *fanOutWriter) Write(b []byte) (int, error) {
+	alive := f.ws[:0]
+	for _, w := range f.ws {
+		if _, err := w.Write(b); err != nil {
+			w.Close()
+			continue
+		}
+		alive = append(alive, w)
+	}
+	f.ws = alive
+	if len(f.ws) == 0 {
+		return 0, io.ErrClosedPipe
+	}
+	return len(b), nil
+}
+
+func (f *fanOutWriter) Close() error {
+	closeFiles(f.ws...)
+	f.ws = nil
+	return nil
 }
 
 func newPipe(writer io.Writer, limit Size) (<-chan struct{}, *os.File, error) {
@@ -35,20 +90,94 @@ func newPipe(writer io.Writer, limit Size) (<-chan struct{}, *os.File, error) {
 	return done, w, nil
 }
 
-func newPipeBuffer(limit Size, newFile NewStoreFile) (*pipeBuffer, error) {
+// newPipeBuffer collects the first limit bytes from the pipe, and the last
+// tail bytes after the truncated marker if tail > 0
+func newPipeBuffer(limit, tail Size, newFile NewStoreFile) (*pipeBuffer, error) {
 	buffer, err := newFile()
 	if err != nil {
 		return nil, err
 	}
-	done, w, err := newPipe(buffer, limit+1)
+	r, w, err := os.Pipe()
 	if err != nil {
 		buffer.Close()
 		return nil, err
 	}
+	size := new(int64)
+	cr := &countReader{Reader: r, n: size}
+	done := make(chan struct{})
+	go func() {
+		defer r.Close()
+		if tail <= 0 {
+			io.CopyN(buffer, cr, int64(limit)+1)
+			close(done)
+			// ensure no blocking / SIGPIPE on the other end
+			io.Copy(io.Discard, cr)
+			return
+		}
+		defer close(done)
+		io.CopyN(buffer, cr, int64(limit))
+		t := &tailWriter{max: int(tail)}
+		io.Copy(t, cr)
+		writeTail(buffer, t.bytes(), atomic.LoadInt64(size)-int64(limit))
+	}()
 	return &pipeBuffer{
 		W:      w,
 		Buffer: buffer,
 		Done:   done,
 		Limit:  limit,
+		Size:   size,
 	}, nil
 }
+
+// tailWriter keeps the last max bytes written
+type tailWriter struct {
+	max int
+	buf []byte
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > 2*t.max {
+		t.buf = append(t.buf[:0], t.buf[len(t.buf)-t.max:]...)
+	}
+	return len(p), nil
+}
+
+func (t *tailWriter) bytes() []byte {
+	if len(t.buf) > t.max {
+		return t.buf[len(t.buf)-t.max:]
+	}
+	return t.buf
+}
+
+// writeTail writes the tail after the truncated marker if the rest of the
+// output after the head is longer than the tail
+func writeTail(w io.Writer, tail []byte, rest int64) error {
+	if truncated := rest - int64(len(tail)); truncated > 0 {
+		if _, err := io.WriteString(w, truncatedMarker(truncated)); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(tail)
+	return err
+}
+
+// copyTail writes the last tail bytes of the file after the head into w, after
+// the truncated marker if the rest is longer than the tail
+func copyTail(w io.Writer, r io.ReaderAt, head, size, tail int64) error {
+	start := size - tail
+	if start < head {
+		start = head
+	}
+	if start > head {
+		if _, err := io.WriteString(w, truncatedMarker(start-head)); err != nil {
+			return err
+		}
+	}
+	_, err := io.Copy(w, io.NewSectionReader(r, start, size-start))
+	return err
+}
+
+func truncatedMarker(n int64) string {
+	return fmt.Sprintf("\n... %d bytes truncated ...\n", n)
+}
@@ -0,0 +1,143 @@
+package envexec
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// fanOutTestData is larger than the pipe buffer to block the writer if any
+// destination is not read
+var fanOutTestData = bytes.Repeat([]byte("0123456789abcdef"), 1<<14)
+
+var fanOutTestPrograms = map[string]testProgram{
+	"write": func(ctx context.Context, args []string, files []*os.File) int {
+		if _, err := files[1].Write(fanOutTestData); err != nil {
+			return 1
+		}
+		return 0
+	},
+	"cat": func(ctx context.Context, args []string, files []*os.File) int {
+		if _, err := io.Copy(files[1], files[0]); err != nil {
+			return 1
+		}
+		return 0
+	},
+	// drop closes the input without reading it
+	"drop": func(ctx context.Context, args []string, files []*os.File) int {
+		files[0].Close()
+		files[0] = nil
+		return 0
+	},
+}
+
+func TestPipeFanOut(t *testing.T) {
+	tests := []struct {
+		name    string
+		readers []string
+		// the proxy stops collecting once all the destinations are closed
+		partial bool
+	}{
+		{"single", []string{"cat"}, false},
+		{"all", []string{"cat", "cat", "cat"}, false},
+		{"drop", []string{"cat", "drop", "cat"}, false},
+		{"drop first", []string{"drop", "cat"}, false},
+		{"drop all", []string{"drop", "drop"}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestRunEnv(t, fanOutTestPrograms)
+			limit := Size(len(fanOutTestData))
+			writer := testRunCmd(env, "write")
+			writer.Files = []File{nil, nil}
+			g := &Group{
+				Cmd:          []*Cmd{writer},
+				NewStoreFile: testStoreFile(t),
+			}
+			p := Pipe{In: PipeIndex{Index: 0, Fd: 1}, Name: "data", Limit: limit, Proxy: true}
+			for i, r := range tc.readers {
+				c := testRunCmd(env, r)
+				c.Files = []File{nil, NewFileCollector("stdout", limit, true)}
+				g.Cmd = append(g.Cmd, c)
+				if i == 0 {
+					p.Out = PipeIndex{Index: 1, Fd: 0}
+				} else {
+					p.FanOut = append(p.FanOut, PipeIndex{Index: i + 1, Fd: 0})
+				}
+			}
+			g.Pipes = []Pipe{p}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			rt, err := g.Run(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.Err() != nil {
+				t.Fatal("fan out pipe blocked the writer")
+			}
+			defer func() {
+				for _, r := range rt {
+					for _, f := range r.Files {
+						f.Close()
+					}
+				}
+			}()
+			for i, r := range rt {
+				if r.Status != StatusAccepted {
+					t.Fatalf("cmd %d: status %v: %s", i, r.Status, r.Error)
+				}
+			}
+			// the proxy collects the data written by the writer
+			data := readFanOutFile(t, "writer", rt[0].Files["data"])
+			if tc.partial && !bytes.HasPrefix(fanOutTestData, data) {
+				t.Errorf("writer: collected %d bytes not written", len(data))
+			}
+			if !tc.partial && !bytes.Equal(data, fanOutTestData) {
+				t.Errorf("writer: expected %d bytes, got %d bytes", len(fanOutTestData), len(data))
+			}
+			for i, r := range tc.readers {
+				var exp []byte
+				if r == "cat" {
+					exp = fanOutTestData
+				}
+				if b := readFanOutFile(t, r, rt[i+1].Files["stdout"]); !bytes.Equal(b, exp) {
+					t.Errorf("%s: expected %d bytes, got %d bytes", r, len(exp), len(b))
+				}
+			}
+		})
+	}
+}
+
+func readFanOutFile(t *testing.T, name string, f *os.File) []byte {
+	t.Helper()
+	if f == nil {
+		t.Errorf("%s: file not collected", name)
+		return nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestPipeOutputs(t *testing.T) {
+	p := Pipe{Out: PipeIndex{Index: 1}, FanOut: []PipeIndex{{Index: 2}, {Index: 3}}}
+	if !p.proxied() {
+		t.Error("fan out pipe is not proxied")
+	}
+	outs := p.outputs()
+	if len(outs) != 3 || outs[0].Index != 1 || outs[1].Index != 2 || outs[2].Index != 3 {
+		t.Errorf("unexpected outputs %v", outs)
+	}
+	if (Pipe{}).proxied() {
+		t.Error("plain pipe is proxied")
+	}
+}
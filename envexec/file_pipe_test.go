@@ -0,0 +1,128 @@
+package envexec
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTailWriter(t *testing.T) {
+	tw := &tailWriter{max: 4}
+	var all []byte
+	for _, s := range []string{"a", "bcdefg", "hi", "", "jklmnopqrstu", "v"} {
+		n, err := tw.Write([]byte(s))
+		if err != nil || n != len(s) {
+			t.Fatalf("write %q: %d, %v", s, n, err)
+		}
+		all = append(all, s...)
+		exp := all
+		if len(exp) > tw.max {
+			exp = exp[len(exp)-tw.max:]
+		}
+		if got := tw.bytes(); !bytes.Equal(got, exp) {
+			t.Errorf("after %q: expected tail %q, got %q", s, exp, got)
+		}
+		if len(tw.buf) > 2*tw.max {
+			t.Errorf("after %q: buffered %d bytes over twice the max", s, len(tw.buf))
+		}
+	}
+}
+
+func TestWriteTail(t *testing.T) {
+	tests := []struct {
+		tail string
+		rest int64
+		exp  string
+	}{
+		{"", 0, ""},
+		{"abc", 3, "abc"},
+		{"abc", 10, truncatedMarker(7) + "abc"},
+	}
+	for _, tc := range tests {
+		var b bytes.Buffer
+		if err := writeTail(&b, []byte(tc.tail), tc.rest); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.exp {
+			t.Errorf("writeTail(%q, %d): expected %q, got %q", tc.tail, tc.rest, tc.exp, b.String())
+		}
+	}
+}
+
+func TestCopyTail(t *testing.T) {
+	const content = "0123456789abcdefghij"
+	tests := []struct {
+		head, tail int64
+		exp        string
+	}{
+		// the tail overlaps with the head
+		{10, 15, "abcdefghij"},
+		// the tail follows the head
+		{10, 10, "abcdefghij"},
+		{10, 4, truncatedMarker(6) + "ghij"},
+		{0, 5, truncatedMarker(15) + "fghij"},
+		{20, 5, ""},
+	}
+	r := strings.NewReader(content)
+	for _, tc := range tests {
+		var b bytes.Buffer
+		if err := copyTail(&b, r, tc.head, int64(len(content)), tc.tail); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.exp {
+			t.Errorf("copyTail(head=%d, tail=%d): expected %q, got %q", tc.head, tc.tail, tc.exp, b.String())
+		}
+	}
+	if m := truncatedMarker(6); !strings.Contains(m, "6 bytes truncated") {
+		t.Errorf("unexpected marker %q", m)
+	}
+}
+
+func TestPipeBufferTail(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit, tail Size
+		input       string
+		exp         string
+	}{
+		{"short", 4, 3, "abc", "abc"},
+		{"tail only", 4, 3, "abcdefg", "abcdefg"},
+		{"truncated", 4, 3, "abcdefghijklmn", "abcd" + truncatedMarker(7) + "lmn"},
+		{"no tail", 4, 0, "abcdefghijklmn", "abcde"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p, err := newPipeBuffer(tc.limit, tc.tail, func() (*os.File, error) {
+				return os.CreateTemp(dir, "")
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer p.Buffer.Close()
+			if _, err := io.WriteString(p.W, tc.input); err != nil {
+				t.Fatal(err)
+			}
+			p.W.Close()
+			<-p.Done
+
+			if _, err := p.Buffer.Seek(0, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(p.Buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.exp {
+				t.Errorf("expected %q, got %q", tc.exp, b)
+			}
+			// without tail, the rest is discarded after done
+			if s := atomic.LoadInt64(p.Size); tc.tail > 0 && s != int64(len(tc.input)) {
+				t.Errorf("expected size %d, got %d", len(tc.input), s)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/creack/pty"
 )
@@ -77,7 +78,7 @@ func prepareCmdFdTTY(c *Cmd, count int, newStoreFile NewStoreFile) (f []*os.File
 			if err != nil {
 				return nil, nil, fmt.Errorf("filed to create store file %v", err)
 			}
-			pipeToCollect = append(pipeToCollect, pipeCollector{done, buf, t.Limit, t.Name})
+			pipeToCollect = append(pipeToCollect, pipeCollector{done, buf, t.Limit, t.Name, nil})
 
 			wg.Add(1)
 			go func() {
@@ -163,16 +164,17 @@ func prepareCmdFd(c *Cmd, count int, newFileStore NewStoreFile) (f []*os.File, p
 			}
 
 			if t.Pipe {
-				b, err := newPipeBuffer(t.Limit, newFileStore)
+				b, err := newPipeBuffer(t.Limit, t.Tail, newFileStore)
 				if err != nil {
 					return nil, nil, fmt.Errorf("failed to create pipe %v", err)
 				}
 				cf[t.Name] = b.W
 
 				files[j] = b.W
-				pipeToCollect = append(pipeToCollect, pipeCollector{b.Done, b.Buffer, t.Limit, t.Name})
+				pipeToCollect = append(pipeToCollect, pipeCollector{b.Done, b.Buffer, t.Limit, t.Name, b.Size})
 			} else {
-				f, err := c.Environment.Open(t.Name, os.O_CREATE|os.O_WRONLY, 0777)
+				// truncate the content left by the previous run in the same environment
+				f, err := c.Environment.Open(t.Name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0777)
 				if err != nil {
 					return nil, nil, fmt.Errorf("filed to create container file %v", err)
 				}
@@ -225,28 +227,77 @@ func prepareFds(r *Group, newStoreFile NewStoreFile) (f [][]*os.File, p [][]pipe
 	}
 
 	// prepare pipes
+	start := time.Now()
+	transcripts := make(map[string]*pipeTranscript)
+	var transcriptOrder []Pipe
+	defer func() {
+		if err != nil {
+			for _, t := range transcripts {
+				t.buffer.Close()
+			}
+		}
+	}()
 	for _, p := range r.Pipes {
-		out, in, pc, err := pipe(p, newStoreFile)
+		var (
+			outs []*os.File
+			in   *os.File
+			pc   *pipeCollector
+		)
+		if p.proxied() && p.Transcript && p.Name != "" {
+			if _, ok := transcripts[p.Name]; !ok {
+				transcriptOrder = append(transcriptOrder, p)
+			}
+			outs, in, err = pipeTranscriptProxy(p, transcripts, start, newStoreFile)
+		} else {
+			outs, in, pc, err = pipe(p, newStoreFile)
+		}
 		if err != nil {
 			return nil, nil, err
 		}
-		files[p.Out.Index][p.Out.Fd] = out
+		for i, o := range p.outputs() {
+			files[o.Index][o.Fd] = outs[i]
+		}
 		files[p.In.Index][p.In.Fd] = in
 
 		if pc != nil {
 			pipeToCollect[p.In.Index] = append(pipeToCollect[p.In.Index], *pc)
 		}
 	}
+	// transcripts are collected by the cmd of the first pipe
+	for _, p := range transcriptOrder {
+		pc := transcripts[p.Name].collector(p.Name)
+		pipeToCollect[p.In.Index] = append(pipeToCollect[p.In.Index], pc)
+	}
 	return files, pipeToCollect, nil
 }
 
+// pipeTranscriptProxy creates the proxied pipe recording into the transcript
+// shared by the name
+func pipeTranscriptProxy(p Pipe, transcripts map[string]*pipeTranscript, start time.Time, newStoreFile NewStoreFile) (outs []*os.File, in *os.File, err error) {
+	t, ok := transcripts[p.Name]
+	if !ok {
+		buffer, err := newStoreFile()
+		if err != nil {
+			return nil, nil, err
+		}
+		t = newPipeTranscript(start, buffer, p.Limit)
+		transcripts[p.Name] = t
+	}
+	out1, in1, dst, outs, err := proxyPipes(len(p.outputs()))
+	if err != nil {
+		return nil, nil, err
+	}
+	t.proxy(p, out1, dst)
+	return outs, in1, nil
+}
+
 func countFd(r *Group) ([]int, error) {
 	fdCount := make([]int, len(r.Cmd))
 	for i, c := range r.Cmd {
 		fdCount[i] = len(c.Files)
 	}
 	for _, pi := range r.Pipes {
-		for _, p := range []PipeIndex{pi.In, pi.Out} {
+		for _, p := range append([]PipeIndex{pi.In}, pi.outputs()...) {
 			if p.Index < 0 || p.Index >= len(r.Cmd) {
 				return nil, fmt.Errorf("pipe index out of range %v", p.Index)
 			}
@@ -261,44 +312,56 @@ func countFd(r *Group) ([]int, error) {
 	return fdCount, nil
 }
 
-func pipe(p Pipe, newStoreFile NewStoreFile) (out *os.File, in *os.File, pc *pipeCollector, err error) {
-	if p.Proxy {
-		buffer, err := newStoreFile()
+func pipe(p Pipe, newStoreFile NewStoreFile) (outs []*os.File, in *os.File, pc *pipeCollector, err error) {
+	if !p.proxied() {
+		out, in, err := os.Pipe()
 		if err != nil {
 			return nil, nil, nil, err
 		}
-		out1, in1, out2, in2, err := pipe2()
-		if err != nil {
-			buffer.Close()
+		return []*os.File{out}, in, nil, nil
+	}
+
+	var buffer *os.File
+	if p.Name != "" {
+		if buffer, err = newStoreFile(); err != nil {
 			return nil, nil, nil, err
 		}
-
-		pc := pipeProxy(p, out1, in2, buffer)
-		return out2, in1, pc, nil
 	}
-
-	out, in, err = os.Pipe()
+	out1, in1, dst, outs, err := proxyPipes(len(p.outputs()))
 	if err != nil {
+		closeFiles(buffer)
 		return nil, nil, nil, err
 	}
-	return out, in, nil, nil
+	pc = pipeProxy(p, out1, dst, buffer)
+	return outs, in1, pc, nil
 }
 
-func pipe2() (out1 *os.File, in1 *os.File, out2 *os.File, in2 *os.File, err error) {
+// proxyPipes creates the pipe of the input end and the pipes of the n output
+// ends, the data written to dst is written to all the output ends
+func proxyPipes(n int) (out1 *os.File, in1 *os.File, dst io.WriteCloser, outs []*os.File, err error) {
 	out1, in1, err = os.Pipe()
 	if err != nil {
-		return
+		return nil, nil, nil, nil, err
 	}
-	out2, in2, err = os.Pipe()
-	if err != nil {
-		out1.Close()
-		in1.Close()
-		return
+	ins := make([]*os.File, 0, n)
+	for i := 0; i < n; i++ {
+		out, in, err := os.Pipe()
+		if err != nil {
+			closeFiles(out1, in1)
+			closeFiles(outs...)
+			closeFiles(ins...)
+			return nil, nil, nil, nil, err
+		}
+		outs = append(outs, out)
+		ins = append(ins, in)
+	}
+	if n == 1 {
+		return out1, in1, ins[0], outs, nil
 	}
-	return
+	return out1, in1, &fanOutWriter{ws: ins}, outs, nil
 }
 
-func pipeProxy(p Pipe, out1 *os.File, in2 *os.File, buffer *os.File) *pipeCollector {
+func pipeProxy(p Pipe, out1 *os.File, in2 io.WriteCloser, buffer *os.File) *pipeCollector {
 	copyAndClose := func() {
 		io.Copy(in2, out1)
 		in2.Close()
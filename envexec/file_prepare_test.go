@@ -0,0 +1,37 @@
+package envexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrepareCmdFdCollectorTruncated(t *testing.T) {
+	env := newTestEnv(t)
+	if err := os.WriteFile(filepath.Join(env.dir, "stdout"), []byte("previous output"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Cmd{
+		Environment: env,
+		Files:       []File{nil, NewFileCollector("stdout", 1024, false)},
+	}
+	files, _, err := prepareCmdFd(c, len(c.Files), func() (*os.File, error) {
+		return os.CreateTemp(t.TempDir(), "")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFiles(files...)
+
+	if _, err := files[1].WriteString("new"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(env.dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "new" {
+		t.Errorf("collected %q, expected %q", b, "new")
+	}
+}
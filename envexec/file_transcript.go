@@ -0,0 +1,112 @@
+package envexec
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pipeTranscript records the chunks copied by the proxies of the pipes sharing
+// the same name into the buffer as JSON lines, the transcript is reported as
+// output limit exceeded once the lines exceeds the limit like pipe collectors
+type pipeTranscript struct {
+	mu     sync.Mutex
+	start  time.Time
+	buffer *os.File
+	limit  Size
+	size   Size
+	full   bool
+	total  int64 // total is the size of all the lines including dropped (atomic)
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// transcriptEntry is a line of the transcript
+type transcriptEntry struct {
+	// Time is the monotonic time in nanoseconds since the pipes were created
+	Time int64               `json:"time"`
+	In   transcriptPipeIndex `json:"in"`
+	Out  transcriptPipeIndex `json:"out"`
+	// Data is encoded as base64 since the chunk may not be valid UTF-8
+	Data []byte `json:"data"`
+}
+
+type transcriptPipeIndex struct {
+	Index int `json:"index"`
+	Fd    int `json:"fd"`
+}
+
+func newPipeTranscript(start time.Time, buffer *os.File, limit Size) *pipeTranscript {
+	return &pipeTranscript{
+		start:  start,
+		buffer: buffer,
+		limit:  limit,
+		done:   make(chan struct{}),
+	}
+}
+
+// record writes the chunk as a line into the transcript, chunks are dropped
+// once the transcript reaches the limit but still counted in total
+func (t *pipeTranscript) record(p Pipe, b []byte) {
+	line, err := json.Marshal(transcriptEntry{
+		Time: int64(time.Since(t.start)),
+		In:   transcriptPipeIndex(p.In),
+		Out:  transcriptPipeIndex(p.Out),
+		Data: b,
+	})
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	atomic.AddInt64(&t.total, int64(len(line)))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.full || t.size+Size(len(line)) > t.limit {
+		t.full = true
+		return
+	}
+	n, _ := t.buffer.Write(line)
+	t.size += Size(n)
+}
+
+// proxy copies the data from out1 to in2 and records the chunks
+func (t *pipeTranscript) proxy(p Pipe, out1 *os.File, in2 io.WriteCloser) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		io.Copy(in2, io.TeeReader(out1, transcriptWriter{t: t, p: p}))
+		in2.Close()
+		io.Copy(io.Discard, out1)
+		out1.Close()
+	}()
+}
+
+// collector waits for all proxies to finish after all pipes are created
+func (t *pipeTranscript) collector(name string) pipeCollector {
+	go func() {
+		t.wg.Wait()
+		close(t.done)
+	}()
+	return pipeCollector{
+		done:   t.done,
+		buffer: t.buffer,
+		limit:  t.limit,
+		name:   name,
+		size:   &t.total,
+	}
+}
+
+type transcriptWriter struct {
+	t *pipeTranscript
+	p Pipe
+}
+
+func (w transcriptWriter) Write(b []byte) (int, error) {
+	w.t.record(w.p, b)
+	return len(b), nil
+}
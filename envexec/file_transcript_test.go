@@ -0,0 +1,75 @@
+package envexec
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPipeTranscript(t *testing.T) {
+	buf, err := os.CreateTemp(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Close()
+
+	tr := newPipeTranscript(time.Now(), buf, 200)
+	p := Pipe{In: PipeIndex{Index: 0, Fd: 1}, Out: PipeIndex{Index: 1, Fd: 0}}
+	data := []byte{0xe4, 0xb8, 0xad, 0xff, 0x00} // split and invalid UTF-8
+	tr.record(p, data[:2])
+	tr.record(p, data[2:])
+
+	pc := tr.collector("transcript")
+	<-pc.done
+	if s := pc.total(); s > pc.limit {
+		t.Fatalf("total %d exceeded the limit %d", s, pc.limit)
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	var got []byte
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		var e transcriptEntry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.In != transcriptPipeIndex(p.In) || e.Out != transcriptPipeIndex(p.Out) {
+			t.Errorf("unexpected direction %v -> %v", e.In, e.Out)
+		}
+		got = append(got, e.Data...)
+	}
+	if string(got) != string(data) {
+		t.Errorf("recorded %v, expected %v", got, data)
+	}
+}
+
+func TestPipeTranscriptLimit(t *testing.T) {
+	buf, err := os.CreateTemp(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Close()
+
+	tr := newPipeTranscript(time.Now(), buf, 100)
+	for i := 0; i < 10; i++ {
+		tr.record(Pipe{}, []byte("0123456789"))
+	}
+	pc := tr.collector("transcript")
+	<-pc.done
+
+	fi, err := buf.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Size(fi.Size()) > pc.limit {
+		t.Errorf("buffer size %d exceeded the limit %d", fi.Size(), pc.limit)
+	}
+	if s := pc.total(); s <= pc.limit {
+		t.Errorf("total %d should exceed the limit %d to report output limit exceeded", s, pc.limit)
+	}
+}
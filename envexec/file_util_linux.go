@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"sort"
+	"strings"
 	"sync/atomic"
-	"syscall"
 
 	"github.com/criyle/go-sandbox/pkg/memfd"
+	"golang.org/x/sys/unix"
 )
 
 const memfdName = "input"
@@ -33,54 +36,180 @@ func readerToFile(reader io.Reader) (*os.File, error) {
 	return r, nil
 }
 
-func copyDir(src *os.File, dst string) error {
+// copyDir dumps the work dir into dst recursively, symlinks and special files
+// are not followed but reported in the manifest
+func copyDir(src *os.File, dst string, max Size) ([]CopyOutDirEntry, error) {
 	// make sure dir exists
-	os.MkdirAll(dst, 0777)
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return nil, err
+	}
 	newDir, err := os.Open(dst)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer newDir.Close()
 
-	dir := src
-	names, err := dir.Readdirnames(-1)
-	if err != nil {
+	d := &dirCopier{max: max}
+	err = d.copyDir(src, int(newDir.Fd()), "")
+	return d.manifest, err
+}
+
+func (d *dirCopier) copyDir(dir *os.File, dstDirFd int, rel string) error {
+	if d.truncated {
+		return nil
+	}
+	// read one more name than the remaining entries to mark the truncation
+	names, err := dir.Readdirnames(d.remain() + 1)
+	if err != nil && err != io.EOF {
 		return err
 	}
+	sort.Strings(names)
+
+	srcDirFd := int(dir.Fd())
 	for _, n := range names {
-		if err := copyFileDir(int(dir.Fd()), int(newDir.Fd()), n); err != nil {
-			return err
+		name := path.Join(rel, n)
+		if !d.visit(name) {
+			return nil
+		}
+		var st unix.Stat_t
+		if err := unix.Fstatat(srcDirFd, n, &st, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+			d.skip(name, 0, 0, err.Error())
+			continue
+		}
+		mode, size := unixFileMode(st.Mode), Size(st.Size)
+
+		switch st.Mode & unix.S_IFMT {
+		case unix.S_IFDIR:
+			i := d.add(name, mode, 0)
+			if !d.enter() {
+				d.manifest[i].Skipped = copyOutDirDepthExceeded
+				continue
+			}
+			err := d.copySubDir(srcDirFd, dstDirFd, n, name)
+			d.leave()
+			if err != nil {
+				d.manifest[i].Skipped = err.Error()
+			}
+
+		case unix.S_IFREG:
+			if !d.reserve(size) {
+				d.skip(name, mode, size, copyOutDirSizeExceeded)
+				continue
+			}
+			if err := copyFileDir(srcDirFd, dstDirFd, n, mode); err != nil {
+				d.skip(name, mode, size, err.Error())
+				continue
+			}
+			d.add(name, mode, size)
+
+		default:
+			d.skip(name, mode, size, skipReason(mode))
 		}
 	}
 	return nil
 }
 
-func copyFileDir(srcDirFd, dstDirFd int, name string) error {
-	// open the source file
-	fd, err := syscall.Openat(srcDirFd, name, syscall.O_CLOEXEC|syscall.O_RDONLY, 0777)
+func (d *dirCopier) copySubDir(srcDirFd, dstDirFd int, n, name string) error {
+	fd, err := unix.Openat(srcDirFd, n, unix.O_CLOEXEC|unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW, 0)
 	if err != nil {
 		return err
 	}
-	defer syscall.Close(fd)
+	dir := os.NewFile(uintptr(fd), name)
+	defer dir.Close()
 
-	var st syscall.Stat_t
-	if err := syscall.Fstat(fd, &st); err != nil {
+	if err := unix.Mkdirat(dstDirFd, n, 0777); err != nil && err != unix.EEXIST {
+		return err
+	}
+	dstFd, err := unix.Openat(dstDirFd, n, unix.O_CLOEXEC|unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW, 0)
+	if err != nil {
 		return err
 	}
-	if st.Mode&syscall.S_IFREG == 0 {
+	defer unix.Close(dstFd)
+
+	return d.copyDir(dir, dstFd, name)
+}
+
+func copyFileDir(srcDirFd, dstDirFd int, name string, mode os.FileMode) error {
+	// open the source file, non-blocking in case it was replaced by a fifo
+	fd, err := unix.Openat(srcDirFd, name, unix.O_CLOEXEC|unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return err
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFREG {
 		return fmt.Errorf("%s is not a regular file", name)
 	}
 
 	// open the dst file
-	dstFd, err := syscall.Openat(dstDirFd, name, syscall.O_CLOEXEC|syscall.O_WRONLY|syscall.O_CREAT|syscall.O_TRUNC, 0777)
+	dstFd, err := unix.Openat(dstDirFd, name, unix.O_CLOEXEC|unix.O_WRONLY|unix.O_CREAT|unix.O_TRUNC|unix.O_NOFOLLOW, uint32(mode.Perm()))
 	if err != nil {
 		return err
 	}
-	defer syscall.Close(dstFd)
+	defer unix.Close(dstFd)
 
-	// send file, ignore error for now if it is dir
-	if _, err := syscall.Sendfile(dstFd, fd, nil, int(st.Size)); err != nil && err != syscall.EINVAL {
-		return err
+	// sendfile may copy less than requested
+	for remain := int(st.Size); remain > 0; {
+		n, err := unix.Sendfile(dstFd, fd, nil, remain)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		remain -= n
 	}
 	return nil
 }
+
+// openAtNoFollow opens the cleaned relative name under the dir without following
+// symlinks in any of its components, the last component is opened as a
+// directory if isDir, or as a non-blocking file otherwise
+func openAtNoFollow(dir *os.File, name string, isDir bool) (*os.File, error) {
+	dirFd := int(dir.Fd())
+	parts := strings.Split(name, "/")
+	for i, n := range parts {
+		flag := unix.O_CLOEXEC | unix.O_RDONLY | unix.O_NOFOLLOW
+		last := i == len(parts)-1
+		if !last || isDir {
+			flag |= unix.O_DIRECTORY
+		} else {
+			flag |= unix.O_NONBLOCK
+		}
+		fd, err := unix.Openat(dirFd, n, flag, 0)
+		if dirFd != int(dir.Fd()) {
+			unix.Close(dirFd)
+		}
+		if err != nil {
+			return nil, &os.PathError{Op: "openat", Path: name, Err: err}
+		}
+		if last {
+			return os.NewFile(uintptr(fd), name), nil
+		}
+		dirFd = fd
+	}
+	return nil, &os.PathError{Op: "openat", Path: name, Err: unix.EINVAL}
+}
+
+func unixFileMode(m uint32) os.FileMode {
+	mode := os.FileMode(m & 0777)
+	switch m & unix.S_IFMT {
+	case unix.S_IFDIR:
+		mode |= os.ModeDir
+	case unix.S_IFLNK:
+		mode |= os.ModeSymlink
+	case unix.S_IFIFO:
+		mode |= os.ModeNamedPipe
+	case unix.S_IFSOCK:
+		mode |= os.ModeSocket
+	case unix.S_IFCHR:
+		mode |= os.ModeDevice | os.ModeCharDevice
+	case unix.S_IFBLK:
+		mode |= os.ModeDevice
+	}
+	return mode
+}
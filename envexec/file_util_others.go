@@ -8,6 +8,9 @@ import (
 	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 func readerToFile(reader io.Reader) (*os.File, error) {
@@ -22,58 +25,134 @@ func readerToFile(reader io.Reader) (*os.File, error) {
 	return r, nil
 }
 
-func copyDir(src *os.File, dst string) error {
+// copyDir dumps the work dir into dst recursively, symlinks and special files
+// are not followed but reported in the manifest
+func copyDir(src *os.File, dst string, max Size) ([]CopyOutDirEntry, error) {
 	// make sure dir exists
-	os.MkdirAll(dst, 0777)
-	newDir, err := os.Open(dst)
-	if err != nil {
-		return err
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return nil, err
 	}
-	defer newDir.Close()
+	d := &dirCopier{max: max}
+	err := d.copyDir(src.Name(), dst, "")
+	return d.manifest, err
+}
 
-	dir, err := os.Open(src.Name())
+func (d *dirCopier) copyDir(src, dst, rel string) error {
+	if d.truncated {
+		return nil
+	}
+	dir, err := os.Open(path.Join(src, rel))
 	if err != nil {
 		return err
 	}
-	defer dir.Close()
-
-	names, err := dir.Readdirnames(-1)
-	if err != nil {
+	// read one more name than the remaining entries to mark the truncation
+	names, err := dir.Readdirnames(d.remain() + 1)
+	dir.Close()
+	if err != nil && err != io.EOF {
 		return err
 	}
+	sort.Strings(names)
+
 	for _, n := range names {
-		if copyDirFile(src.Name(), dst, n); err != nil {
-			return err
+		name := path.Join(rel, n)
+		if !d.visit(name) {
+			return nil
+		}
+		fi, err := os.Lstat(path.Join(src, name))
+		if err != nil {
+			d.skip(name, 0, 0, err.Error())
+			continue
+		}
+		mode, size := fi.Mode(), Size(fi.Size())
+
+		switch {
+		case mode.IsDir():
+			i := d.add(name, mode, 0)
+			if !d.enter() {
+				d.manifest[i].Skipped = copyOutDirDepthExceeded
+				continue
+			}
+			if err := os.Mkdir(path.Join(dst, name), 0777); err != nil && !os.IsExist(err) {
+				d.leave()
+				d.manifest[i].Skipped = err.Error()
+				continue
+			}
+			err := d.copyDir(src, dst, name)
+			d.leave()
+			if err != nil {
+				d.manifest[i].Skipped = err.Error()
+			}
+
+		case mode.IsRegular():
+			if !d.reserve(size) {
+				d.skip(name, mode, size, copyOutDirSizeExceeded)
+				continue
+			}
+			if err := copyDirFile(path.Join(src, name), path.Join(dst, name), mode); err != nil {
+				d.skip(name, mode, size, err.Error())
+				continue
+			}
+			d.add(name, mode, size)
+
+		default:
+			d.skip(name, mode, size, skipReason(mode))
 		}
 	}
 	return nil
 }
 
-func copyDirFile(src, dst, name string) error {
-	s, err := os.Open(path.Join(src, name))
+func copyDirFile(src, dst string, mode os.FileMode) error {
+	s, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer s.Close()
 
-	t, err := os.OpenFile(path.Join(dst, name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	stat, err := s.Stat()
 	if err != nil {
 		return err
 	}
-	defer t.Close()
+	// check regular file
+	if !stat.Mode().IsRegular() {
+		return fmt.Errorf("File(%s) is not a regular file", src)
+	}
 
-	stat, err := t.Stat()
+	t, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
 	if err != nil {
 		return err
 	}
-	// check regular file
-	if stat.Mode()|os.ModeType != 0 {
-		return fmt.Errorf("File(%s) is not a regular file", name)
-	}
+	defer t.Close()
+
+	_, err = t.ReadFrom(io.LimitReader(s, stat.Size()))
+	return err
+}
 
-	_, err = t.ReadFrom(s)
+// openAtNoFollow opens the cleaned relative name under the dir and rejects
+// symlinks in any of its components
+func openAtNoFollow(dir *os.File, name string, isDir bool) (*os.File, error) {
+	p := dir.Name()
+	for _, n := range strings.Split(name, "/") {
+		p = filepath.Join(p, n)
+		fi, err := os.Lstat(p)
+		if err != nil {
+			return nil, err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return nil, &os.PathError{Op: "open", Path: name, Err: fmt.Errorf("is a symlink")}
+		}
+	}
+	f, err := os.Open(p)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if isDir != fi.IsDir() {
+		f.Close()
+		return nil, &os.PathError{Op: "open", Path: name, Err: fmt.Errorf("unexpected file type %v", fi.Mode())}
+	}
+	return f, nil
 }
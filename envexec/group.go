@@ -2,6 +2,8 @@ package envexec
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -16,10 +18,59 @@ type Group struct {
 	// ensure nil is used as placeholder in correspond cmd
 	Pipes []Pipe
 
+	// KillOnExit defines the indexes of the Cmd that terminate the other
+	// running Cmd once they exit (e.g. interactor)
+	KillOnExit []int
+
+	// Policy defines whether the other running Cmd are terminated once any
+	// Cmd exits not accepted, KillOnExit applies regardless of the policy
+	Policy GroupPolicy
+
+	// Limit defines the aggregate limits of all Cmd, which requires the
+	// environment of the first Cmd to implement ResourceGroupEnvironment
+	Limit GroupLimit
+
 	// NewStoreFile defines interface to create stored file
 	NewStoreFile NewStoreFile
 }
 
+// GroupPolicy defines how the exit of a Cmd affects the other running Cmd
+type GroupPolicy int
+
+// Defines the group policies
+const (
+	// GroupWaitAll waits all Cmd to exit by themselves
+	GroupWaitAll GroupPolicy = iota
+	// GroupFailFast terminates the other running Cmd once any Cmd exits not accepted
+	GroupFailFast
+)
+
+var groupPolicyString = []string{
+	"waitAll",
+	"failFast",
+}
+
+func (p GroupPolicy) String() string {
+	i := int(p)
+	if i >= 0 && i < len(groupPolicyString) {
+		return groupPolicyString[i]
+	}
+	return ""
+}
+
+// StringToGroupPolicy converts string to GroupPolicy, empty string is GroupWaitAll
+func StringToGroupPolicy(s string) (GroupPolicy, error) {
+	if s == "" {
+		return GroupWaitAll, nil
+	}
+	for i, v := range groupPolicyString {
+		if v == s {
+			return GroupPolicy(i), nil
+		}
+	}
+	return GroupWaitAll, fmt.Errorf("invalid group policy: %s", s)
+}
+
 // PipeIndex defines the index of cmd and the fd of the that cmd
 type PipeIndex struct {
 	Index int
@@ -40,6 +91,30 @@ type Pipe struct {
 
 	// Proxy creates 2 pipe and connects them by copying data
 	Proxy bool
+
+	// Transcript records the proxied data as JSON lines with the time and the
+	// direction of each chunk instead of the raw content. Proxied pipes with
+	// the same name share the transcript, which is collected by the cmd of the
+	// first of them with the limit of the first of them. The data of each chunk
+	// is encoded as base64 and the collector reports output limit exceeded
+	// once chunks were dropped by the limit
+	Transcript bool
+
+	// FanOut defines the additional output destinations receiving a copy of
+	// the data, the pipe is always proxied if not empty. The proxy writes
+	// each chunk to the destinations in order and is blocked until all of them
+	// accepted it, a destination is dropped once its reader closed it
+	FanOut []PipeIndex
+}
+
+// proxied returns whether the data is copied by the proxy
+func (p Pipe) proxied() bool {
+	return p.Proxy || len(p.FanOut) > 0
+}
+
+// outputs returns the output destination followed by the fan out destinations
+func (p Pipe) outputs() []PipeIndex {
+	return append([]PipeIndex{p.Out}, p.FanOut...)
 }
 
 // Run starts the cmd and returns exec results
@@ -50,13 +125,83 @@ func (r *Group) Run(ctx context.Context) ([]Result, error) {
 		return nil, err
 	}
 
+	// prepare resource group for the aggregate limits
+	var (
+		limiter *groupLimiter
+		rg      ResourceGroup
+	)
+	if r.Limit != (GroupLimit{}) {
+		if limiter, err = newGroupLimiter(r); err != nil {
+			for _, fs := range fds {
+				closeFiles(fs...)
+			}
+			return nil, err
+		}
+		rg = limiter.rg
+		defer rg.Destroy()
+	}
+
+	// each cmd can be terminated separately
+	ctxs := make([]context.Context, len(r.Cmd))
+	cancels := make([]context.CancelFunc, len(r.Cmd))
+	for i := range r.Cmd {
+		ctxs[i], cancels[i] = context.WithCancel(ctx)
+		defer cancels[i]()
+	}
+	killOnExit := make(map[int]bool, len(r.KillOnExit))
+	for _, i := range r.KillOnExit {
+		killOnExit[i] = true
+	}
+
+	var (
+		mu          sync.Mutex
+		finished    = make([]bool, len(r.Cmd))
+		killed      = make([]bool, len(r.Cmd))
+		limitKilled = make([]bool, len(r.Cmd))
+	)
+	// exit terminates the other running cmd by the policy once cmd i exits
+	exit := func(i int, failed bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		finished[i] = true
+		if limitKilled[i] {
+			return
+		}
+		if !killOnExit[i] && !(r.Policy == GroupFailFast && failed) {
+			return
+		}
+		for j, cancel := range cancels {
+			if !finished[j] {
+				killed[j] = true
+				cancel()
+			}
+		}
+	}
+
+	// kill all running cmd once the group limit exceeded
+	if limiter != nil {
+		lctx, lcancel := context.WithCancel(ctx)
+		defer lcancel()
+		go limiter.wait(lctx, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			for j, cancel := range cancels {
+				if !finished[j] {
+					limitKilled[j] = true
+					cancel()
+				}
+			}
+		})
+	}
+
 	// wait all cmd to finish
 	var g errgroup.Group
 	result := make([]Result, len(r.Cmd))
 	for i, c := range r.Cmd {
 		i, c := i, c
 		g.Go(func() error {
-			r, err := runSingle(ctx, c, fds[i], pipeToCollect[i], r.NewStoreFile)
+			r, err := runSingle(ctxs[i], c, fds[i], pipeToCollect[i], r.NewStoreFile, rg)
+			exit(i, err != nil || r.Status != StatusAccepted)
 			result[i] = r
 			if err != nil {
 				result[i].Status = StatusInternalError
@@ -67,5 +212,19 @@ func (r *Group) Run(ctx context.Context) ([]Result, error) {
 		})
 	}
 	err = g.Wait()
+	for i := range result {
+		result[i].Killed = killed[i] && result[i].Status != StatusAccepted
+	}
+	if limiter != nil {
+		// the usage after all cmd exited is checked as well
+		if t := limiter.check(); t != GroupLimitNone {
+			for i := range result {
+				result[i].GroupLimitExceeded = t
+				if result[i].Status == StatusAccepted || limitKilled[i] {
+					result[i].Status = t.status(result[i].Status)
+				}
+			}
+		}
+	}
 	return result, err
 }
@@ -0,0 +1,135 @@
+package envexec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// GroupLimit defines the aggregate resource limits of all Cmd in the Group,
+// zero value means unlimited
+type GroupLimit struct {
+	Time   time.Duration // Time limits the total cpu time
+	Memory Size          // Memory limits the total memory
+	Proc   uint64        // Proc limits the total number of processes
+}
+
+// GroupLimitType defines the group limit that was exceeded
+type GroupLimitType int
+
+// Defines the group limit types
+const (
+	GroupLimitNone GroupLimitType = iota
+	GroupLimitTime
+	GroupLimitMemory
+	GroupLimitProc
+)
+
+var groupLimitTypeString = []string{
+	"",
+	"Time",
+	"Memory",
+	"Proc",
+}
+
+func (t GroupLimitType) String() string {
+	i := int(t)
+	if i >= 0 && i < len(groupLimitTypeString) {
+		return groupLimitTypeString[i]
+	}
+	return ""
+}
+
+// groupLimiter checks the usage of the resource group periodically and kills
+// the group once any of the limits is exceeded
+type groupLimiter struct {
+	rg    ResourceGroup
+	limit GroupLimit
+
+	mu       sync.Mutex
+	memory   Size // memory is the peak memory sampled
+	exceeded GroupLimitType
+}
+
+// newGroupLimiter creates the resource group by the environment of the first cmd
+func newGroupLimiter(r *Group) (*groupLimiter, error) {
+	if len(r.Cmd) == 0 {
+		return nil, fmt.Errorf("group limit: no cmd")
+	}
+	e, ok := r.Cmd[0].Environment.(ResourceGroupEnvironment)
+	if !ok {
+		return nil, fmt.Errorf("group limit: not supported by the environment")
+	}
+	// enforced with more memory to distinguish the exceeded usage
+	limit := r.Limit
+	if limit.Memory > 0 {
+		limit.Memory += defaultExtraMemoryLimit
+	}
+	rg, err := e.NewResourceGroup(limit)
+	if err != nil {
+		return nil, fmt.Errorf("group limit: %v", err)
+	}
+	return &groupLimiter{rg: rg, limit: r.Limit}, nil
+}
+
+// wait checks the limits until the context is done and calls kill once
+// the limit exceeded
+func (l *groupLimiter) wait(ctx context.Context, kill func()) {
+	ticker := time.NewTicker(groupTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if l.check() != GroupLimitNone {
+				kill()
+				return
+			}
+		}
+	}
+}
+
+// check returns the first limit exceeded by the group
+func (l *groupLimiter) check() GroupLimitType {
+	u := l.rg.Usage()
+	t := l.rg.Exceeded()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if u.Memory > l.memory {
+		l.memory = u.Memory
+	}
+	if l.exceeded != GroupLimitNone {
+		return l.exceeded
+	}
+	switch {
+	case t != GroupLimitNone:
+	case l.limit.Time > 0 && u.Time > l.limit.Time:
+		t = GroupLimitTime
+	case l.limit.Memory > 0 && l.memory > l.limit.Memory:
+		t = GroupLimitMemory
+	}
+	l.exceeded = t
+	return t
+}
+
+// status returns the status of the cmd which was accepted or killed by the
+// group limit exceeded
+func (t GroupLimitType) status(s Status) Status {
+	switch t {
+	case GroupLimitTime:
+		return StatusTimeLimitExceeded
+	case GroupLimitMemory:
+		return StatusMemoryLimitExceeded
+	case GroupLimitProc:
+		// the group is killed once the process limit exceeded
+		return StatusSignalled
+	default:
+		return s
+	}
+}
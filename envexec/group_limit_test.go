@@ -0,0 +1,24 @@
+package envexec
+
+import "testing"
+
+func TestGroupLimitTypeStatus(t *testing.T) {
+	tests := []struct {
+		t        GroupLimitType
+		s        Status
+		expected Status
+	}{
+		{GroupLimitNone, StatusAccepted, StatusAccepted},
+		{GroupLimitNone, StatusSignalled, StatusSignalled},
+		{GroupLimitTime, StatusAccepted, StatusTimeLimitExceeded},
+		{GroupLimitTime, StatusSignalled, StatusTimeLimitExceeded},
+		{GroupLimitMemory, StatusAccepted, StatusMemoryLimitExceeded},
+		{GroupLimitProc, StatusAccepted, StatusSignalled},
+		{GroupLimitProc, StatusSignalled, StatusSignalled},
+	}
+	for _, tc := range tests {
+		if got := tc.t.status(tc.s); got != tc.expected {
+			t.Errorf("%v.status(%v) = %v, expected %v", tc.t, tc.s, got, tc.expected)
+		}
+	}
+}
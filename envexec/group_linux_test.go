@@ -0,0 +1,132 @@
+package envexec
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+var groupTestPrograms = map[string]testProgram{
+	"ok": func(ctx context.Context, args []string, files []*os.File) int {
+		return 0
+	},
+	"fail": func(ctx context.Context, args []string, files []*os.File) int {
+		return 1
+	},
+	// sleep exits after a short while
+	"sleep": func(ctx context.Context, args []string, files []*os.File) int {
+		select {
+		case <-ctx.Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+		return 0
+	},
+	// wait runs until it is terminated
+	"wait": func(ctx context.Context, args []string, files []*os.File) int {
+		<-ctx.Done()
+		return 0
+	},
+}
+
+func TestGroupPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		programs   []string
+		policy     GroupPolicy
+		killOnExit []int
+		status     []Status
+		killed     []bool
+	}{
+		{
+			name:     "wait all",
+			programs: []string{"fail", "sleep"},
+			policy:   GroupWaitAll,
+			status:   []Status{StatusNonzeroExitStatus, StatusAccepted},
+			killed:   []bool{false, false},
+		},
+		{
+			name:     "fail fast",
+			programs: []string{"fail", "wait", "wait"},
+			policy:   GroupFailFast,
+			status:   []Status{StatusNonzeroExitStatus, StatusSignalled, StatusSignalled},
+			killed:   []bool{false, true, true},
+		},
+		{
+			name:     "fail fast accepted",
+			programs: []string{"ok", "sleep"},
+			policy:   GroupFailFast,
+			status:   []Status{StatusAccepted, StatusAccepted},
+			killed:   []bool{false, false},
+		},
+		{
+			name:       "kill on exit",
+			programs:   []string{"wait", "ok"},
+			policy:     GroupWaitAll,
+			killOnExit: []int{1},
+			status:     []Status{StatusSignalled, StatusAccepted},
+			killed:     []bool{true, false},
+		},
+		{
+			name:       "kill on exit failed",
+			programs:   []string{"wait", "fail"},
+			policy:     GroupWaitAll,
+			killOnExit: []int{1},
+			status:     []Status{StatusSignalled, StatusNonzeroExitStatus},
+			killed:     []bool{true, false},
+		},
+		{
+			name:       "kill on exit last",
+			programs:   []string{"fail", "ok", "sleep"},
+			policy:     GroupWaitAll,
+			killOnExit: []int{2},
+			status:     []Status{StatusNonzeroExitStatus, StatusAccepted, StatusAccepted},
+			killed:     []bool{false, false, false},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestRunEnv(t, groupTestPrograms)
+			g := &Group{
+				KillOnExit:   tc.killOnExit,
+				Policy:       tc.policy,
+				NewStoreFile: testStoreFile(t),
+			}
+			for _, p := range tc.programs {
+				g.Cmd = append(g.Cmd, testRunCmd(env, p))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			rt, err := g.Run(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.Err() != nil {
+				t.Fatal("group did not terminate the running cmd")
+			}
+			for i, r := range rt {
+				if r.Status != tc.status[i] {
+					t.Errorf("cmd %d: expected status %v, got %v", i, tc.status[i], r.Status)
+				}
+				if r.Killed != tc.killed[i] {
+					t.Errorf("cmd %d: expected killed %v, got %v", i, tc.killed[i], r.Killed)
+				}
+			}
+		})
+	}
+}
+
+func TestStringToGroupPolicy(t *testing.T) {
+	for _, p := range []GroupPolicy{GroupWaitAll, GroupFailFast} {
+		got, err := StringToGroupPolicy(p.String())
+		if err != nil || got != p {
+			t.Errorf("StringToGroupPolicy(%q) = %v, %v", p.String(), got, err)
+		}
+	}
+	if p, err := StringToGroupPolicy(""); err != nil || p != GroupWaitAll {
+		t.Errorf("StringToGroupPolicy(\"\") = %v, %v", p, err)
+	}
+	if _, err := StringToGroupPolicy("other"); err == nil {
+		t.Error("expected error for the invalid policy")
+	}
+}
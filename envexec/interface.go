@@ -25,6 +25,10 @@ type ExecveParam struct {
 
 	// Process Limitations
 	Limit Limit
+
+	// Group is the resource group created by the environment for the Group
+	// the process belongs to, nil if the Group has no limit
+	Group ResourceGroup
 }
 
 // Limit defines the process running resource limits
@@ -44,6 +48,7 @@ type Limit struct {
 type Usage struct {
 	Time   time.Duration
 	Memory Size
+	Output Size // Output is the size collected by pipe and file collectors so far, filled by envexec
 }
 
 // Process reference to the running process group
@@ -59,6 +64,21 @@ type Environment interface {
 	WorkDir() *os.File // WorkDir returns opened work directory, should not close after
 	// Open open file at work dir with given relative path and flags
 	Open(path string, flags int, perm os.FileMode) (*os.File, error)
+	// Mkdir creates directory at work dir with given relative path
+	Mkdir(path string, perm os.FileMode) error
+}
+
+// ResourceGroup aggregates the resource usage of the processes executed within it
+type ResourceGroup interface {
+	Usage() Usage             // Usage retrieves the total usage of the processes
+	Exceeded() GroupLimitType // Exceeded reports the limit enforced by the group that was reached
+	Destroy() error
+}
+
+// ResourceGroupEnvironment defines the Environment that is able to limit the
+// processes of multiple environments in aggregate
+type ResourceGroupEnvironment interface {
+	NewResourceGroup(GroupLimit) (ResourceGroup, error)
 }
 
 // NewStoreFile creates a new file in storage
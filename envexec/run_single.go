@@ -7,23 +7,34 @@ import (
 	"github.com/criyle/go-sandbox/runner"
 )
 
-// runSingle runs Cmd inside the given environment and cgroup
-func runSingle(pc context.Context, c *Cmd, fds []*os.File, ptc []pipeCollector, newStoreFile NewStoreFile) (result Result, err error) {
+// runSingle runs Cmd inside the given environment and cgroup, rg is the
+// resource group of the Group if not nil
+func runSingle(pc context.Context, c *Cmd, fds []*os.File, ptc []pipeCollector, newStoreFile NewStoreFile, rg ResourceGroup) (result Result, err error) {
 	m := c.Environment
 	// copyin
-	if fe, err := runSingleCopyIn(m, c.CopyIn); err != nil {
+	digests, fe, err := runSingleCopyIn(m, c.CopyIn)
+	if err != nil {
 		result.Status = StatusFileError
 		result.Error = err.Error()
 		result.FileError = fe
 		closeFiles(fds...)
 		return result, nil
 	}
+	digests = append(digests, c.CopyInDigests...)
 
 	// run cmd and wait for result
-	rt := runSingleWait(pc, m, c, fds)
+	rt := runSingleWait(pc, m, c, fds, ptc, rg)
 
 	// collect result
-	files, fe, err := copyOutAndCollect(m, c, ptc, newStoreFile)
+	cr, err := copyOutAndCollect(m, c, ptc, newStoreFile)
+	// verify copyin files unchanged
+	if len(digests) > 0 {
+		vfe, verr := verifyCopyIn(m, digests)
+		cr.fileError = append(cr.fileError, vfe...)
+		if err == nil {
+			err = verr
+		}
+	}
 	result = Result{
 		Status:     convertStatus(rt.Status),
 		ExitStatus: rt.ExitStatus,
@@ -31,8 +42,12 @@ func runSingle(pc context.Context, c *Cmd, fds []*os.File, ptc []pipeCollector,
 		Time:       rt.Time,
 		RunTime:    rt.RunningTime,
 		Memory:     rt.Memory,
-		Files:      files,
-		FileError:  fe,
+		Files:      cr.files,
+		FileError:  cr.fileError,
+		CopyOutDir: cr.manifest,
+		OutputSize: cr.outputSize,
+
+		CopyInDigests: digests,
 	}
 	// collect error (only if the process exits normally)
 	if rt.Status == runner.StatusNormal && err != nil && result.Error == "" {
@@ -53,19 +68,24 @@ func runSingle(pc context.Context, c *Cmd, fds []*os.File, ptc []pipeCollector,
 	return result, nil
 }
 
-func runSingleCopyIn(m Environment, copyInFiles map[string]File) ([]FileError, error) {
+func runSingleCopyIn(m Environment, copyInFiles map[string]File) ([]CopyInDigest, []FileError, error) {
 	if len(copyInFiles) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 	return copyIn(m, copyInFiles)
 }
 
-func runSingleWait(pc context.Context, m Environment, c *Cmd, fds []*os.File) RunnerResult {
+func runSingleWait(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []pipeCollector, rg ResourceGroup) RunnerResult {
 	// start the cmd (they will be canceled in other goroutines)
 	ctx, cancel := context.WithCancel(pc)
 	defer cancel()
 
-	process, err := runSingleExecve(ctx, m, c, fds)
+	// output written to the file collectors is tracked by the sizes of the
+	// files prepared for them, which are kept open until the process exits
+	files := fileCollectors(c, fds)
+	defer closeFiles(files...)
+
+	process, err := runSingleExecve(ctx, m, c, fds, files, rg)
 	if err != nil {
 		return runner.Result{
 			Status: runner.StatusRunnerError,
@@ -76,7 +96,7 @@ func runSingleWait(pc context.Context, m Environment, c *Cmd, fds []*os.File) Ru
 	// starts waiter to periodically check cpu usage
 	go func() {
 		defer cancel()
-		c.Waiter(ctx, process)
+		c.Waiter(ctx, &collectProcess{Process: process, ptc: ptc, files: files})
 	}()
 
 	// ensure waiter exit
@@ -84,8 +104,55 @@ func runSingleWait(pc context.Context, m Environment, c *Cmd, fds []*os.File) Ru
 	return process.Result()
 }
 
-func runSingleExecve(ctx context.Context, m Environment, c *Cmd, fds []*os.File) (Process, error) {
-	defer closeFiles(fds...)
+// fileCollectors returns the distinct files prepared for the file collectors
+// (not pipe) to read their sizes. The collectors share the tty in tty mode
+func fileCollectors(c *Cmd, fds []*os.File) []*os.File {
+	if c.TTY {
+		return nil
+	}
+	var files []*os.File
+	seen := make(map[*os.File]bool)
+	for i, f := range c.Files {
+		t, ok := f.(*FileCollector)
+		if !ok || t.Pipe || i >= len(fds) || fds[i] == nil || seen[fds[i]] {
+			continue
+		}
+		seen[fds[i]] = true
+		files = append(files, fds[i])
+	}
+	return files
+}
+
+// collectProcess reports the size collected by the pipe collectors and written
+// to the file collectors as the output usage
+type collectProcess struct {
+	Process
+	ptc   []pipeCollector
+	files []*os.File
+}
+
+func (p *collectProcess) Usage() Usage {
+	u := p.Process.Usage()
+	for i := range p.ptc {
+		u.Output += p.ptc[i].total()
+	}
+	for _, f := range p.files {
+		if fi, err := f.Stat(); err == nil {
+			u.Output += Size(fi.Size())
+		}
+	}
+	return u
+}
+
+// runSingleExecve starts the cmd and closes fds except the ones in keep
+func runSingleExecve(ctx context.Context, m Environment, c *Cmd, fds []*os.File, keep []*os.File, rg ResourceGroup) (Process, error) {
+	defer func() {
+		for _, f := range fds {
+			if !containsFile(keep, f) {
+				closeFiles(f)
+			}
+		}
+	}()
 
 	extraMemoryLimit := c.ExtraMemoryLimit
 	if extraMemoryLimit == 0 {
@@ -119,6 +186,16 @@ func runSingleExecve(ctx context.Context, m Environment, c *Cmd, fds []*os.File)
 			CPUSet:       c.CPUSetLimit,
 			StrictMemory: c.StrictMemoryLimit,
 		},
+		Group: rg,
 	}
 	return m.Execve(ctx, execParam)
 }
+
+func containsFile(files []*os.File, f *os.File) bool {
+	for _, k := range files {
+		if k == f {
+			return true
+		}
+	}
+	return false
+}
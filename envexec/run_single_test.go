@@ -0,0 +1,54 @@
+package envexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testProcess struct{}
+
+func (testProcess) Done() <-chan struct{} { return nil }
+func (testProcess) Result() RunnerResult  { return RunnerResult{} }
+func (testProcess) Usage() Usage          { return Usage{} }
+
+func TestCollectProcessUsageFileCollector(t *testing.T) {
+	env := newTestEnv(t)
+	c := &Cmd{
+		Environment: env,
+		Files: []File{
+			nil,
+			NewFileCollector("stdout", 1024, false),
+			NewFileCollector("stdout", 1024, false),
+			NewFileCollector("stderr", 1024, true),
+		},
+	}
+	newStoreFile := func() (*os.File, error) {
+		return os.CreateTemp(t.TempDir(), "")
+	}
+	fds, ptc, err := prepareCmdFd(c, len(c.Files), newStoreFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFiles(fds...)
+	defer func() {
+		for _, p := range ptc {
+			p.buffer.Close()
+		}
+	}()
+	files := fileCollectors(c, fds)
+	if len(files) != 1 || files[0] != fds[1] {
+		t.Fatalf("expected the file collector fd, got %v", files)
+	}
+
+	p := &collectProcess{Process: testProcess{}, files: files}
+	if u := p.Usage(); u.Output != 0 {
+		t.Errorf("expected output 0, got %d", u.Output)
+	}
+	if err := os.WriteFile(filepath.Join(env.dir, "stdout"), []byte("output"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if u := p.Usage(); u.Output != 6 {
+		t.Errorf("expected output 6, got %d", u.Output)
+	}
+}
@@ -21,6 +21,106 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// GroupPolicy defines whether the running cmd are terminated once any cmd
+// exits not accepted, killOnExit applies regardless of the policy
+type Request_GroupPolicy int32
+
+const (
+	Request_WaitAll  Request_GroupPolicy = 0
+	Request_FailFast Request_GroupPolicy = 1
+)
+
+// Enum value maps for Request_GroupPolicy.
+var (
+	Request_GroupPolicy_name = map[int32]string{
+		0: "WaitAll",
+		1: "FailFast",
+	}
+	Request_GroupPolicy_value = map[string]int32{
+		"WaitAll":  0,
+		"FailFast": 1,
+	}
+)
+
+func (x Request_GroupPolicy) Enum() *Request_GroupPolicy {
+	p := new(Request_GroupPolicy)
+	*p = x
+	return p
+}
+
+func (x Request_GroupPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_GroupPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[0].Descriptor()
+}
+
+func (Request_GroupPolicy) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[0]
+}
+
+func (x Request_GroupPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_GroupPolicy.Descriptor instead.
+func (Request_GroupPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type Request_Compare_CompareMode int32
+
+const (
+	Request_Compare_Exact               Request_Compare_CompareMode = 0
+	Request_Compare_IgnoreTrailingSpace Request_Compare_CompareMode = 1
+	Request_Compare_Token               Request_Compare_CompareMode = 2
+	Request_Compare_Float               Request_Compare_CompareMode = 3
+)
+
+// Enum value maps for Request_Compare_CompareMode.
+var (
+	Request_Compare_CompareMode_name = map[int32]string{
+		0: "Exact",
+		1: "IgnoreTrailingSpace",
+		2: "Token",
+		3: "Float",
+	}
+	Request_Compare_CompareMode_value = map[string]int32{
+		"Exact":               0,
+		"IgnoreTrailingSpace": 1,
+		"Token":               2,
+		"Float":               3,
+	}
+)
+
+func (x Request_Compare_CompareMode) Enum() *Request_Compare_CompareMode {
+	p := new(Request_Compare_CompareMode)
+	*p = x
+	return p
+}
+
+func (x Request_Compare_CompareMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_Compare_CompareMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[1].Descriptor()
+}
+
+func (Request_Compare_CompareMode) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[1]
+}
+
+func (x Request_Compare_CompareMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_Compare_CompareMode.Descriptor instead.
+func (Request_Compare_CompareMode) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 11, 0}
+}
+
 type Response_FileError_ErrorType int32
 
 const (
@@ -33,6 +133,8 @@ const (
 	Response_FileError_CopyOutCreateFile     Response_FileError_ErrorType = 6
 	Response_FileError_CopyOutCopyContent    Response_FileError_ErrorType = 7
 	Response_FileError_CollectSizeExceeded   Response_FileError_ErrorType = 8
+	// CopyInModified reports copyIn file modified or deleted after the run
+	Response_FileError_CopyInModified Response_FileError_ErrorType = 9
 )
 
 // Enum value maps for Response_FileError_ErrorType.
@@ -47,6 +149,7 @@ var (
 		6: "CopyOutCreateFile",
 		7: "CopyOutCopyContent",
 		8: "CollectSizeExceeded",
+		9: "CopyInModified",
 	}
 	Response_FileError_ErrorType_value = map[string]int32{
 		"CopyInOpenFile":        0,
@@ -58,6 +161,7 @@ var (
 		"CopyOutCreateFile":     6,
 		"CopyOutCopyContent":    7,
 		"CollectSizeExceeded":   8,
+		"CopyInModified":        9,
 	}
 )
 
@@ -72,11 +176,11 @@ func (x Response_FileError_ErrorType) String() string {
 }
 
 func (Response_FileError_ErrorType) Descriptor() protoreflect.EnumDescriptor {
-	return file_judge_proto_enumTypes[0].Descriptor()
+	return file_judge_proto_enumTypes[2].Descriptor()
 }
 
 func (Response_FileError_ErrorType) Type() protoreflect.EnumType {
-	return &file_judge_proto_enumTypes[0]
+	return &file_judge_proto_enumTypes[2]
 }
 
 func (x Response_FileError_ErrorType) Number() protoreflect.EnumNumber {
@@ -85,7 +189,7 @@ func (x Response_FileError_ErrorType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Response_FileError_ErrorType.Descriptor instead.
 func (Response_FileError_ErrorType) EnumDescriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 0, 0}
+	return file_judge_proto_rawDescGZIP(), []int{5, 0, 0}
 }
 
 type Response_Result_StatusType int32
@@ -93,8 +197,8 @@ type Response_Result_StatusType int32
 const (
 	Response_Result_Invalid             Response_Result_StatusType = 0
 	Response_Result_Accepted            Response_Result_StatusType = 1
-	Response_Result_WrongAnswer         Response_Result_StatusType = 2 // Not used
-	Response_Result_PartiallyCorrect    Response_Result_StatusType = 3 // Not used
+	Response_Result_WrongAnswer         Response_Result_StatusType = 2
+	Response_Result_PartiallyCorrect    Response_Result_StatusType = 3
 	Response_Result_MemoryLimitExceeded Response_Result_StatusType = 4
 	Response_Result_TimeLimitExceeded   Response_Result_StatusType = 5
 	Response_Result_OutputLimitExceeded Response_Result_StatusType = 6
@@ -102,8 +206,8 @@ const (
 	Response_Result_NonZeroExitStatus   Response_Result_StatusType = 8
 	Response_Result_Signalled           Response_Result_StatusType = 9
 	Response_Result_DangerousSyscall    Response_Result_StatusType = 10
-	Response_Result_JudgementFailed     Response_Result_StatusType = 11 // Not used
-	Response_Result_InvalidInteraction  Response_Result_StatusType = 12 // Not used
+	Response_Result_JudgementFailed     Response_Result_StatusType = 11
+	Response_Result_InvalidInteraction  Response_Result_StatusType = 12
 	Response_Result_InternalError       Response_Result_StatusType = 13
 )
 
@@ -154,11 +258,11 @@ func (x Response_Result_StatusType) String() string {
 }
 
 func (Response_Result_StatusType) Descriptor() protoreflect.EnumDescriptor {
-	return file_judge_proto_enumTypes[1].Descriptor()
+	return file_judge_proto_enumTypes[3].Descriptor()
 }
 
 func (Response_Result_StatusType) Type() protoreflect.EnumType {
-	return &file_judge_proto_enumTypes[1]
+	return &file_judge_proto_enumTypes[3]
 }
 
 func (x Response_Result_StatusType) Number() protoreflect.EnumNumber {
@@ -167,7 +271,59 @@ func (x Response_Result_StatusType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Response_Result_StatusType.Descriptor instead.
 func (Response_Result_StatusType) EnumDescriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 1, 0}
+	return file_judge_proto_rawDescGZIP(), []int{5, 3, 0}
+}
+
+type Response_Result_GroupLimitType int32
+
+const (
+	Response_Result_None   Response_Result_GroupLimitType = 0
+	Response_Result_Time   Response_Result_GroupLimitType = 1
+	Response_Result_Memory Response_Result_GroupLimitType = 2
+	Response_Result_Proc   Response_Result_GroupLimitType = 3
+)
+
+// Enum value maps for Response_Result_GroupLimitType.
+var (
+	Response_Result_GroupLimitType_name = map[int32]string{
+		0: "None",
+		1: "Time",
+		2: "Memory",
+		3: "Proc",
+	}
+	Response_Result_GroupLimitType_value = map[string]int32{
+		"None":   0,
+		"Time":   1,
+		"Memory": 2,
+		"Proc":   3,
+	}
+)
+
+func (x Response_Result_GroupLimitType) Enum() *Response_Result_GroupLimitType {
+	p := new(Response_Result_GroupLimitType)
+	*p = x
+	return p
+}
+
+func (x Response_Result_GroupLimitType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Response_Result_GroupLimitType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[4].Descriptor()
+}
+
+func (Response_Result_GroupLimitType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[4]
+}
+
+func (x Response_Result_GroupLimitType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Response_Result_GroupLimitType.Descriptor instead.
+func (Response_Result_GroupLimitType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 3, 1}
 }
 
 type FileID struct {
@@ -319,6 +475,62 @@ func (x *FileListType) GetFileIDs() map[string]string {
 	return nil
 }
 
+type Parallelism struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parallelism int32 `protobuf:"varint,1,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	// current is the number of running worker loops, ignored by SetParallelism
+	Current int32 `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
+}
+
+func (x *Parallelism) Reset() {
+	*x = Parallelism{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Parallelism) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parallelism) ProtoMessage() {}
+
+func (x *Parallelism) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parallelism.ProtoReflect.Descriptor instead.
+func (*Parallelism) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Parallelism) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *Parallelism) GetCurrent() int32 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
 type Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -327,12 +539,27 @@ type Request struct {
 	RequestID   string             `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
 	Cmd         []*Request_CmdType `protobuf:"bytes,2,rep,name=cmd,proto3" json:"cmd,omitempty"`
 	PipeMapping []*Request_PipeMap `protobuf:"bytes,3,rep,name=pipeMapping,proto3" json:"pipeMapping,omitempty"`
+	// higher priority request is executed first
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// tenant is overridden by the tenant of the auth token if presents
+	Tenant string `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// stages runs in order instead of cmd and stops after a stage not accepted
+	Stages []*Request_Stage `protobuf:"bytes,6,rep,name=stages,proto3" json:"stages,omitempty"`
+	// batch runs test cases instead of cmd with one result for each case
+	Batch *Request_Batch `protobuf:"bytes,7,opt,name=batch,proto3" json:"batch,omitempty"`
+	// interactive runs the solution with the interactor instead of cmd
+	Interactive *Request_Interactive `protobuf:"bytes,8,opt,name=interactive,proto3" json:"interactive,omitempty"`
+	// groupLimit limits all cmd in aggregate
+	GroupLimit  *Request_GroupLimit `protobuf:"bytes,9,opt,name=groupLimit,proto3" json:"groupLimit,omitempty"`
+	GroupPolicy Request_GroupPolicy `protobuf:"varint,10,opt,name=groupPolicy,proto3,enum=pb.Request_GroupPolicy" json:"groupPolicy,omitempty"`
+	// killOnExit terminates the other running cmd once the cmd exits
+	KillOnExit []int32 `protobuf:"varint,11,rep,packed,name=killOnExit,proto3" json:"killOnExit,omitempty"`
 }
 
 func (x *Request) Reset() {
 	*x = Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[3]
+		mi := &file_judge_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -345,7 +572,7 @@ func (x *Request) String() string {
 func (*Request) ProtoMessage() {}
 
 func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[3]
+	mi := &file_judge_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +585,7 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request.ProtoReflect.Descriptor instead.
 func (*Request) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3}
+	return file_judge_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Request) GetRequestID() string {
@@ -382,6 +609,62 @@ func (x *Request) GetPipeMapping() []*Request_PipeMap {
 	return nil
 }
 
+func (x *Request) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Request) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Request) GetStages() []*Request_Stage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *Request) GetBatch() *Request_Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *Request) GetInteractive() *Request_Interactive {
+	if x != nil {
+		return x.Interactive
+	}
+	return nil
+}
+
+func (x *Request) GetGroupLimit() *Request_GroupLimit {
+	if x != nil {
+		return x.GroupLimit
+	}
+	return nil
+}
+
+func (x *Request) GetGroupPolicy() Request_GroupPolicy {
+	if x != nil {
+		return x.GroupPolicy
+	}
+	return Request_WaitAll
+}
+
+func (x *Request) GetKillOnExit() []int32 {
+	if x != nil {
+		return x.KillOnExit
+	}
+	return nil
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -390,12 +673,14 @@ type Response struct {
 	RequestID string             `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
 	Results   []*Response_Result `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 	Error     string             `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// waitTime is the duration (ns) the request waited in the queue
+	WaitTime uint64 `protobuf:"varint,4,opt,name=waitTime,proto3" json:"waitTime,omitempty"`
 }
 
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[4]
+		mi := &file_judge_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +693,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[4]
+	mi := &file_judge_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +706,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4}
+	return file_judge_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Response) GetRequestID() string {
@@ -445,6 +730,13 @@ func (x *Response) GetError() string {
 	return ""
 }
 
+func (x *Response) GetWaitTime() uint64 {
+	if x != nil {
+		return x.WaitTime
+	}
+	return 0
+}
+
 type StreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -460,7 +752,7 @@ type StreamRequest struct {
 func (x *StreamRequest) Reset() {
 	*x = StreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[5]
+		mi := &file_judge_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -473,7 +765,7 @@ func (x *StreamRequest) String() string {
 func (*StreamRequest) ProtoMessage() {}
 
 func (x *StreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[5]
+	mi := &file_judge_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +778,7 @@ func (x *StreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5}
+	return file_judge_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *StreamRequest) GetRequest() isStreamRequest_Request {
@@ -553,7 +845,7 @@ type StreamResponse struct {
 func (x *StreamResponse) Reset() {
 	*x = StreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[6]
+		mi := &file_judge_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -566,7 +858,7 @@ func (x *StreamResponse) String() string {
 func (*StreamResponse) ProtoMessage() {}
 
 func (x *StreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[6]
+	mi := &file_judge_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -579,7 +871,7 @@ func (x *StreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamResponse.ProtoReflect.Descriptor instead.
 func (*StreamResponse) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{6}
+	return file_judge_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *StreamResponse) GetResponse() isStreamResponse_Response {
@@ -630,7 +922,7 @@ type Request_LocalFile struct {
 func (x *Request_LocalFile) Reset() {
 	*x = Request_LocalFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[8]
+		mi := &file_judge_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -643,7 +935,7 @@ func (x *Request_LocalFile) String() string {
 func (*Request_LocalFile) ProtoMessage() {}
 
 func (x *Request_LocalFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[8]
+	mi := &file_judge_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,7 +948,7 @@ func (x *Request_LocalFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_LocalFile.ProtoReflect.Descriptor instead.
 func (*Request_LocalFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 0}
+	return file_judge_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *Request_LocalFile) GetSrc() string {
@@ -677,7 +969,7 @@ type Request_MemoryFile struct {
 func (x *Request_MemoryFile) Reset() {
 	*x = Request_MemoryFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[9]
+		mi := &file_judge_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -690,7 +982,7 @@ func (x *Request_MemoryFile) String() string {
 func (*Request_MemoryFile) ProtoMessage() {}
 
 func (x *Request_MemoryFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[9]
+	mi := &file_judge_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,7 +995,7 @@ func (x *Request_MemoryFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_MemoryFile.ProtoReflect.Descriptor instead.
 func (*Request_MemoryFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 1}
+	return file_judge_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *Request_MemoryFile) GetContent() []byte {
@@ -724,7 +1016,7 @@ type Request_CachedFile struct {
 func (x *Request_CachedFile) Reset() {
 	*x = Request_CachedFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[10]
+		mi := &file_judge_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -737,7 +1029,7 @@ func (x *Request_CachedFile) String() string {
 func (*Request_CachedFile) ProtoMessage() {}
 
 func (x *Request_CachedFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[10]
+	mi := &file_judge_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +1042,7 @@ func (x *Request_CachedFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_CachedFile.ProtoReflect.Descriptor instead.
 func (*Request_CachedFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 2}
+	return file_judge_proto_rawDescGZIP(), []int{4, 2}
 }
 
 func (x *Request_CachedFile) GetFileID() string {
@@ -768,12 +1060,14 @@ type Request_PipeCollector struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Max  int64  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
 	Pipe bool   `protobuf:"varint,3,opt,name=pipe,proto3" json:"pipe,omitempty"`
+	// tail keeps the last bytes after the truncated marker if exceeds max
+	Tail int64 `protobuf:"varint,4,opt,name=tail,proto3" json:"tail,omitempty"`
 }
 
 func (x *Request_PipeCollector) Reset() {
 	*x = Request_PipeCollector{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[11]
+		mi := &file_judge_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -786,7 +1080,7 @@ func (x *Request_PipeCollector) String() string {
 func (*Request_PipeCollector) ProtoMessage() {}
 
 func (x *Request_PipeCollector) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[11]
+	mi := &file_judge_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -799,7 +1093,7 @@ func (x *Request_PipeCollector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_PipeCollector.ProtoReflect.Descriptor instead.
 func (*Request_PipeCollector) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 3}
+	return file_judge_proto_rawDescGZIP(), []int{4, 3}
 }
 
 func (x *Request_PipeCollector) GetName() string {
@@ -823,6 +1117,13 @@ func (x *Request_PipeCollector) GetPipe() bool {
 	return false
 }
 
+func (x *Request_PipeCollector) GetTail() int64 {
+	if x != nil {
+		return x.Tail
+	}
+	return 0
+}
+
 type Request_StreamInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -834,7 +1135,7 @@ type Request_StreamInput struct {
 func (x *Request_StreamInput) Reset() {
 	*x = Request_StreamInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[12]
+		mi := &file_judge_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -847,7 +1148,7 @@ func (x *Request_StreamInput) String() string {
 func (*Request_StreamInput) ProtoMessage() {}
 
 func (x *Request_StreamInput) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[12]
+	mi := &file_judge_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1161,7 @@ func (x *Request_StreamInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_StreamInput.ProtoReflect.Descriptor instead.
 func (*Request_StreamInput) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 4}
+	return file_judge_proto_rawDescGZIP(), []int{4, 4}
 }
 
 func (x *Request_StreamInput) GetName() string {
@@ -881,7 +1182,7 @@ type Request_StreamOutput struct {
 func (x *Request_StreamOutput) Reset() {
 	*x = Request_StreamOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[13]
+		mi := &file_judge_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -894,7 +1195,7 @@ func (x *Request_StreamOutput) String() string {
 func (*Request_StreamOutput) ProtoMessage() {}
 
 func (x *Request_StreamOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[13]
+	mi := &file_judge_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -907,7 +1208,7 @@ func (x *Request_StreamOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_StreamOutput.ProtoReflect.Descriptor instead.
 func (*Request_StreamOutput) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 5}
+	return file_judge_proto_rawDescGZIP(), []int{4, 5}
 }
 
 func (x *Request_StreamOutput) GetName() string {
@@ -917,38 +1218,31 @@ func (x *Request_StreamOutput) GetName() string {
 	return ""
 }
 
-type Request_File struct {
+type Request_StageFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to File:
-	//	*Request_File_Local
-	//	*Request_File_Memory
-	//	*Request_File_Cached
-	//	*Request_File_Pipe
-	//	*Request_File_StreamIn
-	//	*Request_File_StreamOut
-	File isRequest_File_File `protobuf_oneof:"file"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Request_File) Reset() {
-	*x = Request_File{}
+func (x *Request_StageFile) Reset() {
+	*x = Request_StageFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[14]
+		mi := &file_judge_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_File) String() string {
+func (x *Request_StageFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_File) ProtoMessage() {}
+func (*Request_StageFile) ProtoMessage() {}
 
-func (x *Request_File) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[14]
+func (x *Request_StageFile) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,27 +1253,162 @@ func (x *Request_File) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_File.ProtoReflect.Descriptor instead.
-func (*Request_File) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 6}
+// Deprecated: Use Request_StageFile.ProtoReflect.Descriptor instead.
+func (*Request_StageFile) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 6}
 }
 
-func (m *Request_File) GetFile() isRequest_File_File {
-	if m != nil {
-		return m.File
+func (x *Request_StageFile) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Request_File) GetLocal() *Request_LocalFile {
-	if x, ok := x.GetFile().(*Request_File_Local); ok {
-		return x.Local
-	}
-	return nil
+// ArchiveFile is extracted into the directory of the copyIn name
+type Request_ArchiveFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File *Request_File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// format is one of tar, tar.gz (tgz) and zip
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// sizeLimit and inodeLimit override the server default limits if > 0
+	SizeLimit  uint64 `protobuf:"varint,3,opt,name=sizeLimit,proto3" json:"sizeLimit,omitempty"`
+	InodeLimit uint64 `protobuf:"varint,4,opt,name=inodeLimit,proto3" json:"inodeLimit,omitempty"`
 }
 
-func (x *Request_File) GetMemory() *Request_MemoryFile {
-	if x, ok := x.GetFile().(*Request_File_Memory); ok {
+func (x *Request_ArchiveFile) Reset() {
+	*x = Request_ArchiveFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_ArchiveFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_ArchiveFile) ProtoMessage() {}
+
+func (x *Request_ArchiveFile) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_ArchiveFile.ProtoReflect.Descriptor instead.
+func (*Request_ArchiveFile) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 7}
+}
+
+func (x *Request_ArchiveFile) GetFile() *Request_File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Request_ArchiveFile) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Request_ArchiveFile) GetSizeLimit() uint64 {
+	if x != nil {
+		return x.SizeLimit
+	}
+	return 0
+}
+
+func (x *Request_ArchiveFile) GetInodeLimit() uint64 {
+	if x != nil {
+		return x.InodeLimit
+	}
+	return 0
+}
+
+type Request_File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to File:
+	//	*Request_File_Local
+	//	*Request_File_Memory
+	//	*Request_File_Cached
+	//	*Request_File_Pipe
+	//	*Request_File_StreamIn
+	//	*Request_File_StreamOut
+	//	*Request_File_Stage
+	//	*Request_File_Archive
+	File isRequest_File_File `protobuf_oneof:"file"`
+	// mode creates the copyIn file with the permission (0777 if 0) and
+	// verifyUnchanged reports file error if it was modified or deleted after
+	// the run, they are only valid in copyIn
+	Mode            uint32 `protobuf:"varint,9,opt,name=mode,proto3" json:"mode,omitempty"`
+	VerifyUnchanged bool   `protobuf:"varint,10,opt,name=verifyUnchanged,proto3" json:"verifyUnchanged,omitempty"`
+}
+
+func (x *Request_File) Reset() {
+	*x = Request_File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_File) ProtoMessage() {}
+
+func (x *Request_File) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_File.ProtoReflect.Descriptor instead.
+func (*Request_File) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 8}
+}
+
+func (m *Request_File) GetFile() isRequest_File_File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (x *Request_File) GetLocal() *Request_LocalFile {
+	if x, ok := x.GetFile().(*Request_File_Local); ok {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *Request_File) GetMemory() *Request_MemoryFile {
+	if x, ok := x.GetFile().(*Request_File_Memory); ok {
 		return x.Memory
 	}
 	return nil
@@ -1013,6 +1442,34 @@ func (x *Request_File) GetStreamOut() *Request_StreamOutput {
 	return nil
 }
 
+func (x *Request_File) GetStage() *Request_StageFile {
+	if x, ok := x.GetFile().(*Request_File_Stage); ok {
+		return x.Stage
+	}
+	return nil
+}
+
+func (x *Request_File) GetArchive() *Request_ArchiveFile {
+	if x, ok := x.GetFile().(*Request_File_Archive); ok {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *Request_File) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *Request_File) GetVerifyUnchanged() bool {
+	if x != nil {
+		return x.VerifyUnchanged
+	}
+	return false
+}
+
 type isRequest_File_File interface {
 	isRequest_File_File()
 }
@@ -1043,6 +1500,16 @@ type Request_File_StreamOut struct {
 	StreamOut *Request_StreamOutput `protobuf:"bytes,6,opt,name=streamOut,proto3,oneof"`
 }
 
+type Request_File_Stage struct {
+	// stage references file copied out by copyOutStage in previous stages
+	Stage *Request_StageFile `protobuf:"bytes,7,opt,name=stage,proto3,oneof"`
+}
+
+type Request_File_Archive struct {
+	// archive only valid in copyIn
+	Archive *Request_ArchiveFile `protobuf:"bytes,8,opt,name=archive,proto3,oneof"`
+}
+
 func (*Request_File_Local) isRequest_File_File() {}
 
 func (*Request_File_Memory) isRequest_File_File() {}
@@ -1055,17 +1522,24 @@ func (*Request_File_StreamIn) isRequest_File_File() {}
 
 func (*Request_File_StreamOut) isRequest_File_File() {}
 
+func (*Request_File_Stage) isRequest_File_File() {}
+
+func (*Request_File_Archive) isRequest_File_File() {}
+
 type Request_CmdType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Args              []string                  `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
-	Env               []string                  `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
-	Files             []*Request_File           `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
-	Tty               bool                      `protobuf:"varint,13,opt,name=tty,proto3" json:"tty,omitempty"`
-	CpuTimeLimit      uint64                    `protobuf:"varint,4,opt,name=cpuTimeLimit,proto3" json:"cpuTimeLimit,omitempty"`
-	ClockTimeLimit    uint64                    `protobuf:"varint,5,opt,name=clockTimeLimit,proto3" json:"clockTimeLimit,omitempty"`
+	Args           []string        `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Env            []string        `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
+	Files          []*Request_File `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	Tty            bool            `protobuf:"varint,13,opt,name=tty,proto3" json:"tty,omitempty"`
+	CpuTimeLimit   uint64          `protobuf:"varint,4,opt,name=cpuTimeLimit,proto3" json:"cpuTimeLimit,omitempty"`
+	ClockTimeLimit uint64          `protobuf:"varint,5,opt,name=clockTimeLimit,proto3" json:"clockTimeLimit,omitempty"`
+	// idleTimeLimit kills the program when neither its cpu time nor its
+	// collected output increases within the limit (ns)
+	IdleTimeLimit     uint64                    `protobuf:"varint,23,opt,name=idleTimeLimit,proto3" json:"idleTimeLimit,omitempty"`
 	MemoryLimit       uint64                    `protobuf:"varint,6,opt,name=memoryLimit,proto3" json:"memoryLimit,omitempty"`
 	StackLimit        uint64                    `protobuf:"varint,12,opt,name=stackLimit,proto3" json:"stackLimit,omitempty"`
 	ProcLimit         uint64                    `protobuf:"varint,7,opt,name=procLimit,proto3" json:"procLimit,omitempty"`
@@ -1076,13 +1550,25 @@ type Request_CmdType struct {
 	CopyOut           []*Request_CmdCopyOutFile `protobuf:"bytes,9,rep,name=copyOut,proto3" json:"copyOut,omitempty"`
 	CopyOutCached     []*Request_CmdCopyOutFile `protobuf:"bytes,10,rep,name=copyOutCached,proto3" json:"copyOutCached,omitempty"`
 	CopyOutDir        string                    `protobuf:"bytes,11,opt,name=copyOutDir,proto3" json:"copyOutDir,omitempty"`
-	CopyOutMax        uint64                    `protobuf:"varint,14,opt,name=copyOutMax,proto3" json:"copyOutMax,omitempty"`
+	// copyOutDirMax limits the total size of files dumped by copyOutDir
+	CopyOutDirMax uint64 `protobuf:"varint,24,opt,name=copyOutDirMax,proto3" json:"copyOutDirMax,omitempty"`
+	CopyOutMax    uint64 `protobuf:"varint,14,opt,name=copyOutMax,proto3" json:"copyOutMax,omitempty"`
+	// copyOutStage defines files used by the later stages
+	CopyOutStage []*Request_CmdCopyOutFile `protobuf:"bytes,18,rep,name=copyOutStage,proto3" json:"copyOutStage,omitempty"`
+	// compare compares the collected stdout (files[1]) with the expected output
+	Compare *Request_Compare `protobuf:"bytes,19,opt,name=compare,proto3" json:"compare,omitempty"`
+	// checker runs the special judge after the cmd accepted
+	Checker *Request_Checker `protobuf:"bytes,20,opt,name=checker,proto3" json:"checker,omitempty"`
+	// noCache disables the result cache for the cmd
+	NoCache bool `protobuf:"varint,21,opt,name=noCache,proto3" json:"noCache,omitempty"`
+	// usageTimeline records the cpu time and memory usage during the run
+	UsageTimeline bool `protobuf:"varint,22,opt,name=usageTimeline,proto3" json:"usageTimeline,omitempty"`
 }
 
 func (x *Request_CmdType) Reset() {
 	*x = Request_CmdType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[15]
+		mi := &file_judge_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1095,7 +1581,7 @@ func (x *Request_CmdType) String() string {
 func (*Request_CmdType) ProtoMessage() {}
 
 func (x *Request_CmdType) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[15]
+	mi := &file_judge_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1108,7 +1594,7 @@ func (x *Request_CmdType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_CmdType.ProtoReflect.Descriptor instead.
 func (*Request_CmdType) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 7}
+	return file_judge_proto_rawDescGZIP(), []int{4, 9}
 }
 
 func (x *Request_CmdType) GetArgs() []string {
@@ -1153,6 +1639,13 @@ func (x *Request_CmdType) GetClockTimeLimit() uint64 {
 	return 0
 }
 
+func (x *Request_CmdType) GetIdleTimeLimit() uint64 {
+	if x != nil {
+		return x.IdleTimeLimit
+	}
+	return 0
+}
+
 func (x *Request_CmdType) GetMemoryLimit() uint64 {
 	if x != nil {
 		return x.MemoryLimit
@@ -1223,6 +1716,13 @@ func (x *Request_CmdType) GetCopyOutDir() string {
 	return ""
 }
 
+func (x *Request_CmdType) GetCopyOutDirMax() uint64 {
+	if x != nil {
+		return x.CopyOutDirMax
+	}
+	return 0
+}
+
 func (x *Request_CmdType) GetCopyOutMax() uint64 {
 	if x != nil {
 		return x.CopyOutMax
@@ -1230,32 +1730,70 @@ func (x *Request_CmdType) GetCopyOutMax() uint64 {
 	return 0
 }
 
-type Request_CmdCopyOutFile struct {
+func (x *Request_CmdType) GetCopyOutStage() []*Request_CmdCopyOutFile {
+	if x != nil {
+		return x.CopyOutStage
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCompare() *Request_Compare {
+	if x != nil {
+		return x.Compare
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetChecker() *Request_Checker {
+	if x != nil {
+		return x.Checker
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetUsageTimeline() bool {
+	if x != nil {
+		return x.UsageTimeline
+	}
+	return false
+}
+
+// Checker runs with arguments `input output answer` appended (testlib)
+type Request_Checker struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Optional bool   `protobuf:"varint,2,opt,name=optional,proto3" json:"optional,omitempty"`
+	Cmd *Request_CmdType `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	// input defaults to files[0] of the cmd
+	Input  *Request_File `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Answer *Request_File `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
 }
 
-func (x *Request_CmdCopyOutFile) Reset() {
-	*x = Request_CmdCopyOutFile{}
+func (x *Request_Checker) Reset() {
+	*x = Request_Checker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[16]
+		mi := &file_judge_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_CmdCopyOutFile) String() string {
+func (x *Request_Checker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_CmdCopyOutFile) ProtoMessage() {}
+func (*Request_Checker) ProtoMessage() {}
 
-func (x *Request_CmdCopyOutFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[16]
+func (x *Request_Checker) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1266,54 +1804,60 @@ func (x *Request_CmdCopyOutFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_CmdCopyOutFile.ProtoReflect.Descriptor instead.
-func (*Request_CmdCopyOutFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 8}
+// Deprecated: Use Request_Checker.ProtoReflect.Descriptor instead.
+func (*Request_Checker) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 10}
 }
 
-func (x *Request_CmdCopyOutFile) GetName() string {
+func (x *Request_Checker) GetCmd() *Request_CmdType {
 	if x != nil {
-		return x.Name
+		return x.Cmd
 	}
-	return ""
+	return nil
 }
 
-func (x *Request_CmdCopyOutFile) GetOptional() bool {
+func (x *Request_Checker) GetInput() *Request_File {
 	if x != nil {
-		return x.Optional
+		return x.Input
 	}
-	return false
+	return nil
 }
 
-type Request_PipeMap struct {
+func (x *Request_Checker) GetAnswer() *Request_File {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+type Request_Compare struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	In    *Request_PipeMap_PipeIndex `protobuf:"bytes,1,opt,name=in,proto3" json:"in,omitempty"`
-	Out   *Request_PipeMap_PipeIndex `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
-	Proxy bool                       `protobuf:"varint,3,opt,name=proxy,proto3" json:"proxy,omitempty"`
-	Name  string                     `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Max   uint64                     `protobuf:"varint,5,opt,name=max,proto3" json:"max,omitempty"`
+	Expected     *Request_File               `protobuf:"bytes,1,opt,name=expected,proto3" json:"expected,omitempty"`
+	Mode         Request_Compare_CompareMode `protobuf:"varint,2,opt,name=mode,proto3,enum=pb.Request_Compare_CompareMode" json:"mode,omitempty"`
+	AbsTolerance float64                     `protobuf:"fixed64,3,opt,name=absTolerance,proto3" json:"absTolerance,omitempty"`
+	RelTolerance float64                     `protobuf:"fixed64,4,opt,name=relTolerance,proto3" json:"relTolerance,omitempty"`
 }
 
-func (x *Request_PipeMap) Reset() {
-	*x = Request_PipeMap{}
+func (x *Request_Compare) Reset() {
+	*x = Request_Compare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[17]
+		mi := &file_judge_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_PipeMap) String() string {
+func (x *Request_Compare) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_PipeMap) ProtoMessage() {}
+func (*Request_Compare) ProtoMessage() {}
 
-func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[17]
+func (x *Request_Compare) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,72 +1868,67 @@ func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_PipeMap.ProtoReflect.Descriptor instead.
-func (*Request_PipeMap) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 9}
-}
-
-func (x *Request_PipeMap) GetIn() *Request_PipeMap_PipeIndex {
-	if x != nil {
-		return x.In
-	}
-	return nil
+// Deprecated: Use Request_Compare.ProtoReflect.Descriptor instead.
+func (*Request_Compare) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 11}
 }
 
-func (x *Request_PipeMap) GetOut() *Request_PipeMap_PipeIndex {
+func (x *Request_Compare) GetExpected() *Request_File {
 	if x != nil {
-		return x.Out
+		return x.Expected
 	}
 	return nil
 }
 
-func (x *Request_PipeMap) GetProxy() bool {
+func (x *Request_Compare) GetMode() Request_Compare_CompareMode {
 	if x != nil {
-		return x.Proxy
+		return x.Mode
 	}
-	return false
+	return Request_Compare_Exact
 }
 
-func (x *Request_PipeMap) GetName() string {
+func (x *Request_Compare) GetAbsTolerance() float64 {
 	if x != nil {
-		return x.Name
+		return x.AbsTolerance
 	}
-	return ""
+	return 0
 }
 
-func (x *Request_PipeMap) GetMax() uint64 {
+func (x *Request_Compare) GetRelTolerance() float64 {
 	if x != nil {
-		return x.Max
+		return x.RelTolerance
 	}
 	return 0
 }
 
-type Request_PipeMap_PipeIndex struct {
+type Request_CmdCopyOutFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Fd    int32 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Optional bool   `protobuf:"varint,2,opt,name=optional,proto3" json:"optional,omitempty"`
+	// archive packs the directory name into the archive copied out as name
+	Archive *Request_CopyOutArchive `protobuf:"bytes,3,opt,name=archive,proto3" json:"archive,omitempty"`
 }
 
-func (x *Request_PipeMap_PipeIndex) Reset() {
-	*x = Request_PipeMap_PipeIndex{}
+func (x *Request_CmdCopyOutFile) Reset() {
+	*x = Request_CmdCopyOutFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[19]
+		mi := &file_judge_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_PipeMap_PipeIndex) String() string {
+func (x *Request_CmdCopyOutFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_PipeMap_PipeIndex) ProtoMessage() {}
+func (*Request_CmdCopyOutFile) ProtoMessage() {}
 
-func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[19]
+func (x *Request_CmdCopyOutFile) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1400,12 +1939,617 @@ func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_PipeMap_PipeIndex.ProtoReflect.Descriptor instead.
-func (*Request_PipeMap_PipeIndex) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 9, 0}
+// Deprecated: Use Request_CmdCopyOutFile.ProtoReflect.Descriptor instead.
+func (*Request_CmdCopyOutFile) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 12}
 }
 
-func (x *Request_PipeMap_PipeIndex) GetIndex() int32 {
+func (x *Request_CmdCopyOutFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Request_CmdCopyOutFile) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *Request_CmdCopyOutFile) GetArchive() *Request_CopyOutArchive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+type Request_CopyOutArchive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// format is one of tar, tar.gz (tgz) and zip
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// patterns select files by their paths relative to the directory
+	Patterns []string `protobuf:"bytes,2,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	MaxFiles uint64   `protobuf:"varint,3,opt,name=maxFiles,proto3" json:"maxFiles,omitempty"`
+}
+
+func (x *Request_CopyOutArchive) Reset() {
+	*x = Request_CopyOutArchive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_CopyOutArchive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_CopyOutArchive) ProtoMessage() {}
+
+func (x *Request_CopyOutArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_CopyOutArchive.ProtoReflect.Descriptor instead.
+func (*Request_CopyOutArchive) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 13}
+}
+
+func (x *Request_CopyOutArchive) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Request_CopyOutArchive) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *Request_CopyOutArchive) GetMaxFiles() uint64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+type Request_PipeMap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	In    *Request_PipeMap_PipeIndex `protobuf:"bytes,1,opt,name=in,proto3" json:"in,omitempty"`
+	Out   *Request_PipeMap_PipeIndex `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
+	Proxy bool                       `protobuf:"varint,3,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	Name  string                     `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Max   uint64                     `protobuf:"varint,5,opt,name=max,proto3" json:"max,omitempty"`
+	// transcript records the proxied chunks as JSON lines with base64 data
+	Transcript bool `protobuf:"varint,6,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	// fanOut copies the data to the additional output ends through the proxy
+	FanOut []*Request_PipeMap_PipeIndex `protobuf:"bytes,7,rep,name=fanOut,proto3" json:"fanOut,omitempty"`
+}
+
+func (x *Request_PipeMap) Reset() {
+	*x = Request_PipeMap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_PipeMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_PipeMap) ProtoMessage() {}
+
+func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_PipeMap.ProtoReflect.Descriptor instead.
+func (*Request_PipeMap) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 14}
+}
+
+func (x *Request_PipeMap) GetIn() *Request_PipeMap_PipeIndex {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *Request_PipeMap) GetOut() *Request_PipeMap_PipeIndex {
+	if x != nil {
+		return x.Out
+	}
+	return nil
+}
+
+func (x *Request_PipeMap) GetProxy() bool {
+	if x != nil {
+		return x.Proxy
+	}
+	return false
+}
+
+func (x *Request_PipeMap) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Request_PipeMap) GetMax() uint64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Request_PipeMap) GetTranscript() bool {
+	if x != nil {
+		return x.Transcript
+	}
+	return false
+}
+
+func (x *Request_PipeMap) GetFanOut() []*Request_PipeMap_PipeIndex {
+	if x != nil {
+		return x.FanOut
+	}
+	return nil
+}
+
+// GroupLimit limits all cmd of the group in aggregate
+type Request_GroupLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CpuTimeLimit uint64 `protobuf:"varint,1,opt,name=cpuTimeLimit,proto3" json:"cpuTimeLimit,omitempty"`
+	MemoryLimit  uint64 `protobuf:"varint,2,opt,name=memoryLimit,proto3" json:"memoryLimit,omitempty"`
+	ProcLimit    uint64 `protobuf:"varint,3,opt,name=procLimit,proto3" json:"procLimit,omitempty"`
+}
+
+func (x *Request_GroupLimit) Reset() {
+	*x = Request_GroupLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_GroupLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_GroupLimit) ProtoMessage() {}
+
+func (x *Request_GroupLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_GroupLimit.ProtoReflect.Descriptor instead.
+func (*Request_GroupLimit) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 15}
+}
+
+func (x *Request_GroupLimit) GetCpuTimeLimit() uint64 {
+	if x != nil {
+		return x.CpuTimeLimit
+	}
+	return 0
+}
+
+func (x *Request_GroupLimit) GetMemoryLimit() uint64 {
+	if x != nil {
+		return x.MemoryLimit
+	}
+	return 0
+}
+
+func (x *Request_GroupLimit) GetProcLimit() uint64 {
+	if x != nil {
+		return x.ProcLimit
+	}
+	return 0
+}
+
+type Request_Stage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cmd         []*Request_CmdType   `protobuf:"bytes,1,rep,name=cmd,proto3" json:"cmd,omitempty"`
+	PipeMapping []*Request_PipeMap   `protobuf:"bytes,2,rep,name=pipeMapping,proto3" json:"pipeMapping,omitempty"`
+	Batch       *Request_Batch       `protobuf:"bytes,3,opt,name=batch,proto3" json:"batch,omitempty"`
+	Interactive *Request_Interactive `protobuf:"bytes,4,opt,name=interactive,proto3" json:"interactive,omitempty"`
+	GroupLimit  *Request_GroupLimit  `protobuf:"bytes,5,opt,name=groupLimit,proto3" json:"groupLimit,omitempty"`
+	GroupPolicy Request_GroupPolicy  `protobuf:"varint,6,opt,name=groupPolicy,proto3,enum=pb.Request_GroupPolicy" json:"groupPolicy,omitempty"`
+	// killOnExit terminates the other running cmd once the cmd exits
+	KillOnExit []int32 `protobuf:"varint,7,rep,packed,name=killOnExit,proto3" json:"killOnExit,omitempty"`
+}
+
+func (x *Request_Stage) Reset() {
+	*x = Request_Stage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Stage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Stage) ProtoMessage() {}
+
+func (x *Request_Stage) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Stage.ProtoReflect.Descriptor instead.
+func (*Request_Stage) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 16}
+}
+
+func (x *Request_Stage) GetCmd() []*Request_CmdType {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *Request_Stage) GetPipeMapping() []*Request_PipeMap {
+	if x != nil {
+		return x.PipeMapping
+	}
+	return nil
+}
+
+func (x *Request_Stage) GetBatch() *Request_Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *Request_Stage) GetInteractive() *Request_Interactive {
+	if x != nil {
+		return x.Interactive
+	}
+	return nil
+}
+
+func (x *Request_Stage) GetGroupLimit() *Request_GroupLimit {
+	if x != nil {
+		return x.GroupLimit
+	}
+	return nil
+}
+
+func (x *Request_Stage) GetGroupPolicy() Request_GroupPolicy {
+	if x != nil {
+		return x.GroupPolicy
+	}
+	return Request_WaitAll
+}
+
+func (x *Request_Stage) GetKillOnExit() []int32 {
+	if x != nil {
+		return x.KillOnExit
+	}
+	return nil
+}
+
+// Interactive connects stdin and stdout of the solution and the interactor
+// both ways, the solution is killed if the interactor exits first
+type Request_Interactive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Solution   *Request_CmdType `protobuf:"bytes,1,opt,name=solution,proto3" json:"solution,omitempty"`
+	Interactor *Request_CmdType `protobuf:"bytes,2,opt,name=interactor,proto3" json:"interactor,omitempty"`
+	// transcriptMax enables capturing data written by each side into the file
+	// named transcript of its result
+	TranscriptMax uint64 `protobuf:"varint,3,opt,name=transcriptMax,proto3" json:"transcriptMax,omitempty"`
+}
+
+func (x *Request_Interactive) Reset() {
+	*x = Request_Interactive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Interactive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Interactive) ProtoMessage() {}
+
+func (x *Request_Interactive) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Interactive.ProtoReflect.Descriptor instead.
+func (*Request_Interactive) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 17}
+}
+
+func (x *Request_Interactive) GetSolution() *Request_CmdType {
+	if x != nil {
+		return x.Solution
+	}
+	return nil
+}
+
+func (x *Request_Interactive) GetInteractor() *Request_CmdType {
+	if x != nil {
+		return x.Interactor
+	}
+	return nil
+}
+
+func (x *Request_Interactive) GetTranscriptMax() uint64 {
+	if x != nil {
+		return x.TranscriptMax
+	}
+	return 0
+}
+
+type Request_BatchCase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// stdin overrides the first file of the cmd
+	Stdin          *Request_File `protobuf:"bytes,1,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	CpuTimeLimit   uint64        `protobuf:"varint,2,opt,name=cpuTimeLimit,proto3" json:"cpuTimeLimit,omitempty"`
+	ClockTimeLimit uint64        `protobuf:"varint,3,opt,name=clockTimeLimit,proto3" json:"clockTimeLimit,omitempty"`
+	MemoryLimit    uint64        `protobuf:"varint,4,opt,name=memoryLimit,proto3" json:"memoryLimit,omitempty"`
+	// expected overrides the expected output of the cmd compare
+	Expected *Request_File `protobuf:"bytes,5,opt,name=expected,proto3" json:"expected,omitempty"`
+	// answer overrides the answer of the cmd checker
+	Answer *Request_File `protobuf:"bytes,6,opt,name=answer,proto3" json:"answer,omitempty"`
+}
+
+func (x *Request_BatchCase) Reset() {
+	*x = Request_BatchCase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_BatchCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_BatchCase) ProtoMessage() {}
+
+func (x *Request_BatchCase) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_BatchCase.ProtoReflect.Descriptor instead.
+func (*Request_BatchCase) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 18}
+}
+
+func (x *Request_BatchCase) GetStdin() *Request_File {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *Request_BatchCase) GetCpuTimeLimit() uint64 {
+	if x != nil {
+		return x.CpuTimeLimit
+	}
+	return 0
+}
+
+func (x *Request_BatchCase) GetClockTimeLimit() uint64 {
+	if x != nil {
+		return x.ClockTimeLimit
+	}
+	return 0
+}
+
+func (x *Request_BatchCase) GetMemoryLimit() uint64 {
+	if x != nil {
+		return x.MemoryLimit
+	}
+	return 0
+}
+
+func (x *Request_BatchCase) GetExpected() *Request_File {
+	if x != nil {
+		return x.Expected
+	}
+	return nil
+}
+
+func (x *Request_BatchCase) GetAnswer() *Request_File {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+// Batch runs cases one after another inside the same environment
+type Request_Batch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cmd           *Request_CmdType     `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	Cases         []*Request_BatchCase `protobuf:"bytes,2,rep,name=cases,proto3" json:"cases,omitempty"`
+	StopOnFailure bool                 `protobuf:"varint,3,opt,name=stopOnFailure,proto3" json:"stopOnFailure,omitempty"`
+}
+
+func (x *Request_Batch) Reset() {
+	*x = Request_Batch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Batch) ProtoMessage() {}
+
+func (x *Request_Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Batch.ProtoReflect.Descriptor instead.
+func (*Request_Batch) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 19}
+}
+
+func (x *Request_Batch) GetCmd() *Request_CmdType {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *Request_Batch) GetCases() []*Request_BatchCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *Request_Batch) GetStopOnFailure() bool {
+	if x != nil {
+		return x.StopOnFailure
+	}
+	return false
+}
+
+type Request_PipeMap_PipeIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Fd    int32 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+}
+
+func (x *Request_PipeMap_PipeIndex) Reset() {
+	*x = Request_PipeMap_PipeIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_PipeMap_PipeIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_PipeMap_PipeIndex) ProtoMessage() {}
+
+func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_PipeMap_PipeIndex.ProtoReflect.Descriptor instead.
+func (*Request_PipeMap_PipeIndex) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 14, 0}
+}
+
+func (x *Request_PipeMap_PipeIndex) GetIndex() int32 {
 	if x != nil {
 		return x.Index
 	}
@@ -1432,7 +2576,7 @@ type Response_FileError struct {
 func (x *Response_FileError) Reset() {
 	*x = Response_FileError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[20]
+		mi := &file_judge_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1445,7 +2589,7 @@ func (x *Response_FileError) String() string {
 func (*Response_FileError) ProtoMessage() {}
 
 func (x *Response_FileError) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[20]
+	mi := &file_judge_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1458,7 +2602,7 @@ func (x *Response_FileError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response_FileError.ProtoReflect.Descriptor instead.
 func (*Response_FileError) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 0}
+	return file_judge_proto_rawDescGZIP(), []int{5, 0}
 }
 
 func (x *Response_FileError) GetName() string {
@@ -1482,39 +2626,362 @@ func (x *Response_FileError) GetMessage() string {
 	return ""
 }
 
-type Response_Result struct {
+// UsageTimeline stores the usage samples in columns, elapsed time (ns), cpu
+// time (ns) and memory (byte) of the same index belong to the same sample
+type Response_UsageTimeline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Elapsed []uint64 `protobuf:"varint,1,rep,packed,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Time    []uint64 `protobuf:"varint,2,rep,packed,name=time,proto3" json:"time,omitempty"`
+	Memory  []uint64 `protobuf:"varint,3,rep,packed,name=memory,proto3" json:"memory,omitempty"`
+}
+
+func (x *Response_UsageTimeline) Reset() {
+	*x = Response_UsageTimeline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response_UsageTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response_UsageTimeline) ProtoMessage() {}
+
+func (x *Response_UsageTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response_UsageTimeline.ProtoReflect.Descriptor instead.
+func (*Response_UsageTimeline) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Response_UsageTimeline) GetElapsed() []uint64 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+func (x *Response_UsageTimeline) GetTime() []uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Response_UsageTimeline) GetMemory() []uint64 {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+// CopyOutDirEntry defines a file or a directory visited by copyOutDir, the
+// file is not dumped if skipped is not empty
+type Response_CopyOutDirEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mode    string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size    uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Skipped string `protobuf:"bytes,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *Response_CopyOutDirEntry) Reset() {
+	*x = Response_CopyOutDirEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response_CopyOutDirEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response_CopyOutDirEntry) ProtoMessage() {}
+
+func (x *Response_CopyOutDirEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response_CopyOutDirEntry.ProtoReflect.Descriptor instead.
+func (*Response_CopyOutDirEntry) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 2}
+}
+
+func (x *Response_CopyOutDirEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Response_CopyOutDirEntry) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Response_CopyOutDirEntry) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Response_CopyOutDirEntry) GetSkipped() string {
+	if x != nil {
+		return x.Skipped
+	}
+	return ""
+}
+
+type Response_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status     Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
+	ExitStatus int32                      `protobuf:"varint,2,opt,name=exitStatus,proto3" json:"exitStatus,omitempty"`
+	Error      string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Time       uint64                     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	RunTime    uint64                     `protobuf:"varint,8,opt,name=runTime,proto3" json:"runTime,omitempty"`
+	Memory     uint64                     `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`
+	Files      map[string][]byte          `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FileIDs    map[string]string          `protobuf:"bytes,7,rep,name=fileIDs,proto3" json:"fileIDs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FileError  []*Response_FileError      `protobuf:"bytes,9,rep,name=fileError,proto3" json:"fileError,omitempty"`
+	// diff summarizes the first mismatch of the output compare
+	Diff string `protobuf:"bytes,10,opt,name=diff,proto3" json:"diff,omitempty"`
+	// score and checkerMessage are reported by the checker
+	Score          float64                 `protobuf:"fixed64,11,opt,name=score,proto3" json:"score,omitempty"`
+	CheckerMessage string                  `protobuf:"bytes,12,opt,name=checkerMessage,proto3" json:"checkerMessage,omitempty"`
+	UsageTimeline  *Response_UsageTimeline `protobuf:"bytes,13,opt,name=usageTimeline,proto3" json:"usageTimeline,omitempty"`
+	// attempts are the runs if the cmd was run again by the retry policy
+	Attempts []*Response_Attempt `protobuf:"bytes,14,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	// copyOutDir is the manifest of the files visited by copyOutDir
+	CopyOutDir []*Response_CopyOutDirEntry `protobuf:"bytes,15,rep,name=copyOutDir,proto3" json:"copyOutDir,omitempty"`
+	// outputSize is the number of bytes written by the program to each collector
+	OutputSize map[string]uint64 `protobuf:"bytes,16,rep,name=outputSize,proto3" json:"outputSize,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// groupLimitExceeded is the aggregate limit of the group that was exceeded
+	GroupLimitExceeded Response_Result_GroupLimitType `protobuf:"varint,17,opt,name=groupLimitExceeded,proto3,enum=pb.Response_Result_GroupLimitType" json:"groupLimitExceeded,omitempty"`
+	// killed indicates the cmd was terminated because another cmd in the group exited
+	Killed bool `protobuf:"varint,18,opt,name=killed,proto3" json:"killed,omitempty"`
+}
+
+func (x *Response_Result) Reset() {
+	*x = Response_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response_Result) ProtoMessage() {}
+
+func (x *Response_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response_Result.ProtoReflect.Descriptor instead.
+func (*Response_Result) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 3}
+}
+
+func (x *Response_Result) GetStatus() Response_Result_StatusType {
+	if x != nil {
+		return x.Status
+	}
+	return Response_Result_Invalid
+}
+
+func (x *Response_Result) GetExitStatus() int32 {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return 0
+}
+
+func (x *Response_Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Response_Result) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Response_Result) GetRunTime() uint64 {
+	if x != nil {
+		return x.RunTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetMemory() uint64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Response_Result) GetFiles() map[string][]byte {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Response_Result) GetFileIDs() map[string]string {
+	if x != nil {
+		return x.FileIDs
+	}
+	return nil
+}
+
+func (x *Response_Result) GetFileError() []*Response_FileError {
+	if x != nil {
+		return x.FileError
+	}
+	return nil
+}
+
+func (x *Response_Result) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *Response_Result) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Response_Result) GetCheckerMessage() string {
+	if x != nil {
+		return x.CheckerMessage
+	}
+	return ""
+}
+
+func (x *Response_Result) GetUsageTimeline() *Response_UsageTimeline {
+	if x != nil {
+		return x.UsageTimeline
+	}
+	return nil
+}
+
+func (x *Response_Result) GetAttempts() []*Response_Attempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *Response_Result) GetCopyOutDir() []*Response_CopyOutDirEntry {
+	if x != nil {
+		return x.CopyOutDir
+	}
+	return nil
+}
+
+func (x *Response_Result) GetOutputSize() map[string]uint64 {
+	if x != nil {
+		return x.OutputSize
+	}
+	return nil
+}
+
+func (x *Response_Result) GetGroupLimitExceeded() Response_Result_GroupLimitType {
+	if x != nil {
+		return x.GroupLimitExceeded
+	}
+	return Response_Result_None
+}
+
+func (x *Response_Result) GetKilled() bool {
+	if x != nil {
+		return x.Killed
+	}
+	return false
+}
+
+type Response_Attempt struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
-	ExitStatus int32                      `protobuf:"varint,2,opt,name=exitStatus,proto3" json:"exitStatus,omitempty"`
-	Error      string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Time       uint64                     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
-	RunTime    uint64                     `protobuf:"varint,8,opt,name=runTime,proto3" json:"runTime,omitempty"`
-	Memory     uint64                     `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`
-	Files      map[string][]byte          `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	FileIDs    map[string]string          `protobuf:"bytes,7,rep,name=fileIDs,proto3" json:"fileIDs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	FileError  []*Response_FileError      `protobuf:"bytes,9,rep,name=fileError,proto3" json:"fileError,omitempty"`
+	Status  Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
+	Time    uint64                     `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	RunTime uint64                     `protobuf:"varint,3,opt,name=runTime,proto3" json:"runTime,omitempty"`
+	Memory  uint64                     `protobuf:"varint,4,opt,name=memory,proto3" json:"memory,omitempty"`
 }
 
-func (x *Response_Result) Reset() {
-	*x = Response_Result{}
+func (x *Response_Attempt) Reset() {
+	*x = Response_Attempt{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[21]
+		mi := &file_judge_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Response_Result) String() string {
+func (x *Response_Attempt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response_Result) ProtoMessage() {}
+func (*Response_Attempt) ProtoMessage() {}
 
-func (x *Response_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[21]
+func (x *Response_Attempt) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,74 +2992,39 @@ func (x *Response_Result) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response_Result.ProtoReflect.Descriptor instead.
-func (*Response_Result) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 1}
+// Deprecated: Use Response_Attempt.ProtoReflect.Descriptor instead.
+func (*Response_Attempt) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 4}
 }
 
-func (x *Response_Result) GetStatus() Response_Result_StatusType {
+func (x *Response_Attempt) GetStatus() Response_Result_StatusType {
 	if x != nil {
 		return x.Status
 	}
 	return Response_Result_Invalid
 }
 
-func (x *Response_Result) GetExitStatus() int32 {
-	if x != nil {
-		return x.ExitStatus
-	}
-	return 0
-}
-
-func (x *Response_Result) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
-}
-
-func (x *Response_Result) GetTime() uint64 {
+func (x *Response_Attempt) GetTime() uint64 {
 	if x != nil {
 		return x.Time
 	}
 	return 0
 }
 
-func (x *Response_Result) GetRunTime() uint64 {
+func (x *Response_Attempt) GetRunTime() uint64 {
 	if x != nil {
 		return x.RunTime
 	}
 	return 0
 }
 
-func (x *Response_Result) GetMemory() uint64 {
+func (x *Response_Attempt) GetMemory() uint64 {
 	if x != nil {
 		return x.Memory
 	}
 	return 0
 }
 
-func (x *Response_Result) GetFiles() map[string][]byte {
-	if x != nil {
-		return x.Files
-	}
-	return nil
-}
-
-func (x *Response_Result) GetFileIDs() map[string]string {
-	if x != nil {
-		return x.FileIDs
-	}
-	return nil
-}
-
-func (x *Response_Result) GetFileError() []*Response_FileError {
-	if x != nil {
-		return x.FileError
-	}
-	return nil
-}
-
 type StreamRequest_Input struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1605,7 +3037,7 @@ type StreamRequest_Input struct {
 func (x *StreamRequest_Input) Reset() {
 	*x = StreamRequest_Input{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[24]
+		mi := &file_judge_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1618,7 +3050,7 @@ func (x *StreamRequest_Input) String() string {
 func (*StreamRequest_Input) ProtoMessage() {}
 
 func (x *StreamRequest_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[24]
+	mi := &file_judge_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,7 +3063,7 @@ func (x *StreamRequest_Input) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRequest_Input.ProtoReflect.Descriptor instead.
 func (*StreamRequest_Input) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5, 0}
+	return file_judge_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *StreamRequest_Input) GetName() string {
@@ -1663,7 +3095,7 @@ type StreamRequest_Resize struct {
 func (x *StreamRequest_Resize) Reset() {
 	*x = StreamRequest_Resize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[25]
+		mi := &file_judge_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1676,7 +3108,7 @@ func (x *StreamRequest_Resize) String() string {
 func (*StreamRequest_Resize) ProtoMessage() {}
 
 func (x *StreamRequest_Resize) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[25]
+	mi := &file_judge_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +3121,7 @@ func (x *StreamRequest_Resize) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRequest_Resize.ProtoReflect.Descriptor instead.
 func (*StreamRequest_Resize) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5, 1}
+	return file_judge_proto_rawDescGZIP(), []int{6, 1}
 }
 
 func (x *StreamRequest_Resize) GetName() string {
@@ -1739,7 +3171,7 @@ type StreamResponse_Output struct {
 func (x *StreamResponse_Output) Reset() {
 	*x = StreamResponse_Output{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[26]
+		mi := &file_judge_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1752,7 +3184,7 @@ func (x *StreamResponse_Output) String() string {
 func (*StreamResponse_Output) ProtoMessage() {}
 
 func (x *StreamResponse_Output) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[26]
+	mi := &file_judge_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1765,7 +3197,7 @@ func (x *StreamResponse_Output) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamResponse_Output.ProtoReflect.Descriptor instead.
 func (*StreamResponse_Output) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{6, 0}
+	return file_judge_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *StreamResponse_Output) GetName() string {
@@ -1802,245 +3234,482 @@ var file_judge_proto_rawDesc = []byte{
 	0x44, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0xb1, 0x0d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x25, 0x0a,
-	0x03, 0x63, 0x6d, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x03, 0x63, 0x6d, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70,
-	0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x0b,
-	0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x1a, 0x1d, 0x0a, 0x09, 0x4c,
-	0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x1a, 0x26, 0x0a, 0x0a, 0x4d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x1a, 0x24, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x49, 0x0a, 0x0d, 0x50, 0x69, 0x70, 0x65,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
-	0x12, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x70,
-	0x69, 0x70, 0x65, 0x1a, 0x21, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x22, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0xc3, 0x02, 0x0a, 0x04, 0x46,
-	0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x63,
-	0x61, 0x6c, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03,
+	0x02, 0x38, 0x01, 0x22, 0x49, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69,
+	0x73, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65,
+	0x6c, 0x69, 0x73, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x9f,
+	0x22, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12,
+	0x35, 0x0a, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x39,
+	0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x0b, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x39, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6b, 0x69, 0x6c, 0x6c, 0x4f, 0x6e, 0x45, 0x78, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x4f, 0x6e, 0x45, 0x78, 0x69, 0x74, 0x1a, 0x1d, 0x0a, 0x09,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x1a, 0x26, 0x0a, 0x0a, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x1a, 0x24, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x5d, 0x0a, 0x0d, 0x50, 0x69, 0x70,
+	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x70, 0x69, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x1a, 0x21, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x22, 0x0a, 0x0c, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a,
+	0x1f, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x1a, 0x89, 0x01, 0x0a, 0x0b, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x24, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x6e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0xe5, 0x03, 0x0a,
+	0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06,
-	0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x48,
-	0x00, 0x52, 0x04, 0x70, 0x69, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x49, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x12, 0x38,
-	0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x1a, 0xc7, 0x05, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x65,
-	0x6e, 0x76, 0x12, 0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74,
-	0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x0c,
-	0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x74, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00,
+	0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x49, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
+	0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52,
+	0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x55, 0x6e, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x55, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x06, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x1a, 0xf1, 0x07, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79,
+	0x12, 0x22, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c,
+	0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0d,
+	0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x70, 0x75, 0x53, 0x65, 0x74,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x70, 0x75,
+	0x53, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79,
+	0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x12,
+	0x34, 0x0a, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d,
+	0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x63, 0x6f,
+	0x70, 0x79, 0x4f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x43, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f,
+	0x75, 0x74, 0x44, 0x69, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f,
+	0x75, 0x74, 0x44, 0x69, 0x72, 0x4d, 0x61, 0x78, 0x18, 0x18, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x4d, 0x61, 0x78, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x3e, 0x0a,
+	0x0c, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x18, 0x12, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x6d, 0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x0c, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x07,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x73, 0x61, 0x67, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x73,
+	0x61, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x4b, 0x0a, 0x0b, 0x43,
+	0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x82, 0x01, 0x0a, 0x07, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43,
+	0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x26, 0x0a, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x1a, 0xfd, 0x01,
+	0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x61, 0x62, 0x73, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0c, 0x61, 0x62, 0x73, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x54, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x47, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x78, 0x61, 0x63, 0x74, 0x10, 0x00, 0x12, 0x17,
+	0x0a, 0x13, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67,
+	0x53, 0x70, 0x61, 0x63, 0x65, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x10, 0x03, 0x1a, 0x76, 0x0a,
+	0x0e, 0x43, 0x6d, 0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12,
+	0x34, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f,
+	0x70, 0x79, 0x4f, 0x75, 0x74, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x07, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x1a, 0x60, 0x0a, 0x0e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0xaf, 0x02, 0x0a, 0x07, 0x50, 0x69, 0x70, 0x65,
+	0x4d, 0x61, 0x70, 0x12, 0x2d, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x02,
+	0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x03,
+	0x6f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
+	0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
+	0x35, 0x0a, 0x06, 0x66, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x06,
+	0x66, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x1a, 0x31, 0x0a, 0x09, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x66, 0x64, 0x1a, 0x70, 0x0a, 0x0a, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69,
+	0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63,
+	0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0xdc, 0x02, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x35, 0x0a, 0x0b,
+	0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50,
+	0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x39, 0x0a, 0x0b,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x39, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6b, 0x69,
+	0x6c, 0x6c, 0x4f, 0x6e, 0x45, 0x78, 0x69, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a,
+	0x6b, 0x69, 0x6c, 0x6c, 0x4f, 0x6e, 0x45, 0x78, 0x69, 0x74, 0x1a, 0x99, 0x01, 0x0a, 0x0b, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x0a, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4d, 0x61,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x4d, 0x61, 0x78, 0x1a, 0xf9, 0x01, 0x0a, 0x09, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x61, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x04, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
 	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
 	0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74,
-	0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
-	0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
-	0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x52,
-	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
-	0x63, 0x70, 0x75, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b,
-	0x63, 0x70, 0x75, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x63, 0x70, 0x75, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c,
-	0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69,
-	0x6d, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63,
-	0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x06,
-	0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63,
-	0x6f, 0x70, 0x79, 0x49, 0x6e, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
-	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0d, 0x63,
-	0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x43, 0x6d, 0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0d,
-	0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a,
-	0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a,
-	0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x1a, 0x4b, 0x0a,
-	0x0b, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x0e, 0x43, 0x6d,
-	0x64, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x1a, 0xd8, 0x01, 0x0a,
-	0x07, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x2d, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x03, 0x6d, 0x61, 0x78, 0x1a, 0x31, 0x0a, 0x09, 0x50, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x02, 0x66, 0x64, 0x22, 0xbd, 0x09, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x49, 0x44, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x08,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x1a, 0x81, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x03,
+	0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03,
+	0x63, 0x6d, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x61, 0x73, 0x65, 0x52, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x4f, 0x6e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x28, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x46, 0x61, 0x73, 0x74, 0x10, 0x01,
+	0x22, 0xe7, 0x10, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x2d, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x77, 0x61, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x1a, 0xdf, 0x02, 0x0a,
+	0x09, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xed,
+	0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e,
+	0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x4f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x00,
+	0x12, 0x14, 0x0a, 0x10, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e,
+	0x43, 0x6f, 0x70, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x0f, 0x0a,
+	0x0b, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4f, 0x70, 0x65, 0x6e, 0x10, 0x03, 0x12, 0x19,
+	0x0a, 0x15, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x67, 0x75,
+	0x6c, 0x61, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x10,
+	0x07, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x69, 0x7a, 0x65,
+	0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f,
+	0x70, 0x79, 0x49, 0x6e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x09, 0x1a, 0x55,
+	0x0a, 0x0d, 0x55, 0x73, 0x61, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04,
+	0x52, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x1a, 0x67, 0x0a, 0x0f, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x44, 0x69, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x1a, 0xaf,
+	0x0a, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0xcb, 0x02, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xd9, 0x01, 0x0a, 0x09, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x70, 0x79, 0x49,
-	0x6e, 0x4f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x43,
-	0x6f, 0x70, 0x79, 0x49, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x10,
-	0x01, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x43, 0x6f, 0x70, 0x79, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79,
-	0x4f, 0x75, 0x74, 0x4f, 0x70, 0x65, 0x6e, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x70,
-	0x79, 0x4f, 0x75, 0x74, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x46, 0x69,
-	0x6c, 0x65, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x53,
-	0x69, 0x7a, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x05, 0x12, 0x15, 0x0a,
-	0x11, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43,
-	0x6f, 0x70, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65,
-	0x64, 0x65, 0x64, 0x10, 0x08, 0x1a, 0xff, 0x05, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78,
-	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69,
-	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x66, 0x69,
-	0x6c, 0x65, 0x49, 0x44, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66,
-	0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x38, 0x0a, 0x0a,
-	0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0xa2, 0x02, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0x00, 0x12, 0x0c,
-	0x0a, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b,
-	0x57, 0x72, 0x6f, 0x6e, 0x67, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x10, 0x02, 0x12, 0x14, 0x0a,
-	0x10, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63,
-	0x74, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11,
-	0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65,
-	0x64, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09,
-	0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x4e,
-	0x6f, 0x6e, 0x5a, 0x65, 0x72, 0x6f, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x10,
-	0x09, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x6f, 0x75, 0x73, 0x53, 0x79,
-	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x75, 0x64, 0x67, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x0b, 0x12, 0x16, 0x0a, 0x12,
-	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x0d, 0x22, 0xd9, 0x02, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0b, 0x65, 0x78, 0x65,
-	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
-	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x65,
-	0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x78,
-	0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78, 0x65, 0x63, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x7a,
-	0x65, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x1a,
-	0x35, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x60, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x0c, 0x0a, 0x01,
-	0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x65, 0x78,
-	0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x65, 0x78,
-	0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x65,
-	0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x1a, 0x36, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42,
-	0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x9e, 0x02, 0x0a, 0x08,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x04, 0x45, 0x78, 0x65, 0x63,
-	0x12, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x45,
-	0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70,
-	0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x28, 0x01, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x46, 0x69,
-	0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49,
-	0x44, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x12, 0x0f, 0x2e,
-	0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x0a,
-	0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x0a, 0x46, 0x69,
-	0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x1f, 0x5a, 0x1d,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x69, 0x79, 0x6c,
-	0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x75,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x34, 0x0a,
+	0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x12,
+	0x34, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x66, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x0d, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63,
+	0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f,
+	0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x63,
+	0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x43, 0x0a, 0x0a, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x52,
+	0x0a, 0x12, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65,
+	0x65, 0x64, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x12,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x3d, 0x0a, 0x0f, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xa2, 0x02, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x72, 0x6f,
+	0x6e, 0x67, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x10, 0x03,
+	0x12, 0x17, 0x0a, 0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45,
+	0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x69, 0x6d,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x05,
+	0x12, 0x17, 0x0a, 0x13, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45,
+	0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x69, 0x6c,
+	0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x6f, 0x6e, 0x5a,
+	0x65, 0x72, 0x6f, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0x08, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x09, 0x12, 0x14,
+	0x0a, 0x10, 0x44, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x6f, 0x75, 0x73, 0x53, 0x79, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x0b, 0x12, 0x16, 0x0a, 0x12, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10,
+	0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x10, 0x0d, 0x22, 0x3a, 0x0a, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x72, 0x6f, 0x63, 0x10, 0x03,
+	0x1a, 0x87, 0x01, 0x0a, 0x07, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x36, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0xd9, 0x02, 0x0a, 0x0d, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0b,
+	0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a,
+	0x09, 0x65, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78, 0x65,
+	0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65,
+	0x73, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x1a, 0x35, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x60, 0x0a, 0x06, 0x52, 0x65, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0c, 0x65, 0x78, 0x65,
+	0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x0c, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a,
+	0x65, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x1a, 0x36, 0x0a, 0x06, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8d,
+	0x03, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x04, 0x45,
+	0x78, 0x65, 0x63, 0x12, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
+	0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x11, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a,
+	0x07, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x64, 0x64,
+	0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x1a, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x30, 0x0a,
+	0x0a, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x70, 0x62,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x39, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73,
+	0x6d, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x32, 0x0a, 0x0e, 0x53, 0x65,
+	0x74, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x1a, 0x0f, 0x2e,
+	0x70, 0x62, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x42, 0x1f,
+	0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x69,
+	0x79, 0x6c, 0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2055,85 +3724,144 @@ func file_judge_proto_rawDescGZIP() []byte {
 	return file_judge_proto_rawDescData
 }
 
-var file_judge_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_judge_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_judge_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_judge_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_judge_proto_goTypes = []interface{}{
-	(Response_FileError_ErrorType)(0), // 0: pb.Response.FileError.ErrorType
-	(Response_Result_StatusType)(0),   // 1: pb.Response.Result.StatusType
-	(*FileID)(nil),                    // 2: pb.FileID
-	(*FileContent)(nil),               // 3: pb.FileContent
-	(*FileListType)(nil),              // 4: pb.FileListType
-	(*Request)(nil),                   // 5: pb.Request
-	(*Response)(nil),                  // 6: pb.Response
-	(*StreamRequest)(nil),             // 7: pb.StreamRequest
-	(*StreamResponse)(nil),            // 8: pb.StreamResponse
-	nil,                               // 9: pb.FileListType.FileIDsEntry
-	(*Request_LocalFile)(nil),         // 10: pb.Request.LocalFile
-	(*Request_MemoryFile)(nil),        // 11: pb.Request.MemoryFile
-	(*Request_CachedFile)(nil),        // 12: pb.Request.CachedFile
-	(*Request_PipeCollector)(nil),     // 13: pb.Request.PipeCollector
-	(*Request_StreamInput)(nil),       // 14: pb.Request.StreamInput
-	(*Request_StreamOutput)(nil),      // 15: pb.Request.StreamOutput
-	(*Request_File)(nil),              // 16: pb.Request.File
-	(*Request_CmdType)(nil),           // 17: pb.Request.CmdType
-	(*Request_CmdCopyOutFile)(nil),    // 18: pb.Request.CmdCopyOutFile
-	(*Request_PipeMap)(nil),           // 19: pb.Request.PipeMap
-	nil,                               // 20: pb.Request.CmdType.CopyInEntry
-	(*Request_PipeMap_PipeIndex)(nil), // 21: pb.Request.PipeMap.PipeIndex
-	(*Response_FileError)(nil),        // 22: pb.Response.FileError
-	(*Response_Result)(nil),           // 23: pb.Response.Result
-	nil,                               // 24: pb.Response.Result.FilesEntry
-	nil,                               // 25: pb.Response.Result.FileIDsEntry
-	(*StreamRequest_Input)(nil),       // 26: pb.StreamRequest.Input
-	(*StreamRequest_Resize)(nil),      // 27: pb.StreamRequest.Resize
-	(*StreamResponse_Output)(nil),     // 28: pb.StreamResponse.Output
-	(*emptypb.Empty)(nil),             // 29: google.protobuf.Empty
+	(Request_GroupPolicy)(0),            // 0: pb.Request.GroupPolicy
+	(Request_Compare_CompareMode)(0),    // 1: pb.Request.Compare.CompareMode
+	(Response_FileError_ErrorType)(0),   // 2: pb.Response.FileError.ErrorType
+	(Response_Result_StatusType)(0),     // 3: pb.Response.Result.StatusType
+	(Response_Result_GroupLimitType)(0), // 4: pb.Response.Result.GroupLimitType
+	(*FileID)(nil),                      // 5: pb.FileID
+	(*FileContent)(nil),                 // 6: pb.FileContent
+	(*FileListType)(nil),                // 7: pb.FileListType
+	(*Parallelism)(nil),                 // 8: pb.Parallelism
+	(*Request)(nil),                     // 9: pb.Request
+	(*Response)(nil),                    // 10: pb.Response
+	(*StreamRequest)(nil),               // 11: pb.StreamRequest
+	(*StreamResponse)(nil),              // 12: pb.StreamResponse
+	nil,                                 // 13: pb.FileListType.FileIDsEntry
+	(*Request_LocalFile)(nil),           // 14: pb.Request.LocalFile
+	(*Request_MemoryFile)(nil),          // 15: pb.Request.MemoryFile
+	(*Request_CachedFile)(nil),          // 16: pb.Request.CachedFile
+	(*Request_PipeCollector)(nil),       // 17: pb.Request.PipeCollector
+	(*Request_StreamInput)(nil),         // 18: pb.Request.StreamInput
+	(*Request_StreamOutput)(nil),        // 19: pb.Request.StreamOutput
+	(*Request_StageFile)(nil),           // 20: pb.Request.StageFile
+	(*Request_ArchiveFile)(nil),         // 21: pb.Request.ArchiveFile
+	(*Request_File)(nil),                // 22: pb.Request.File
+	(*Request_CmdType)(nil),             // 23: pb.Request.CmdType
+	(*Request_Checker)(nil),             // 24: pb.Request.Checker
+	(*Request_Compare)(nil),             // 25: pb.Request.Compare
+	(*Request_CmdCopyOutFile)(nil),      // 26: pb.Request.CmdCopyOutFile
+	(*Request_CopyOutArchive)(nil),      // 27: pb.Request.CopyOutArchive
+	(*Request_PipeMap)(nil),             // 28: pb.Request.PipeMap
+	(*Request_GroupLimit)(nil),          // 29: pb.Request.GroupLimit
+	(*Request_Stage)(nil),               // 30: pb.Request.Stage
+	(*Request_Interactive)(nil),         // 31: pb.Request.Interactive
+	(*Request_BatchCase)(nil),           // 32: pb.Request.BatchCase
+	(*Request_Batch)(nil),               // 33: pb.Request.Batch
+	nil,                                 // 34: pb.Request.CmdType.CopyInEntry
+	(*Request_PipeMap_PipeIndex)(nil),   // 35: pb.Request.PipeMap.PipeIndex
+	(*Response_FileError)(nil),          // 36: pb.Response.FileError
+	(*Response_UsageTimeline)(nil),      // 37: pb.Response.UsageTimeline
+	(*Response_CopyOutDirEntry)(nil),    // 38: pb.Response.CopyOutDirEntry
+	(*Response_Result)(nil),             // 39: pb.Response.Result
+	(*Response_Attempt)(nil),            // 40: pb.Response.Attempt
+	nil,                                 // 41: pb.Response.Result.FilesEntry
+	nil,                                 // 42: pb.Response.Result.FileIDsEntry
+	nil,                                 // 43: pb.Response.Result.OutputSizeEntry
+	(*StreamRequest_Input)(nil),         // 44: pb.StreamRequest.Input
+	(*StreamRequest_Resize)(nil),        // 45: pb.StreamRequest.Resize
+	(*StreamResponse_Output)(nil),       // 46: pb.StreamResponse.Output
+	(*emptypb.Empty)(nil),               // 47: google.protobuf.Empty
 }
 var file_judge_proto_depIdxs = []int32{
-	9,  // 0: pb.FileListType.fileIDs:type_name -> pb.FileListType.FileIDsEntry
-	17, // 1: pb.Request.cmd:type_name -> pb.Request.CmdType
-	19, // 2: pb.Request.pipeMapping:type_name -> pb.Request.PipeMap
-	23, // 3: pb.Response.results:type_name -> pb.Response.Result
-	5,  // 4: pb.StreamRequest.execRequest:type_name -> pb.Request
-	26, // 5: pb.StreamRequest.execInput:type_name -> pb.StreamRequest.Input
-	27, // 6: pb.StreamRequest.execResize:type_name -> pb.StreamRequest.Resize
-	6,  // 7: pb.StreamResponse.execResponse:type_name -> pb.Response
-	28, // 8: pb.StreamResponse.execOutput:type_name -> pb.StreamResponse.Output
-	10, // 9: pb.Request.File.local:type_name -> pb.Request.LocalFile
-	11, // 10: pb.Request.File.memory:type_name -> pb.Request.MemoryFile
-	12, // 11: pb.Request.File.cached:type_name -> pb.Request.CachedFile
-	13, // 12: pb.Request.File.pipe:type_name -> pb.Request.PipeCollector
-	14, // 13: pb.Request.File.streamIn:type_name -> pb.Request.StreamInput
-	15, // 14: pb.Request.File.streamOut:type_name -> pb.Request.StreamOutput
-	16, // 15: pb.Request.CmdType.files:type_name -> pb.Request.File
-	20, // 16: pb.Request.CmdType.copyIn:type_name -> pb.Request.CmdType.CopyInEntry
-	18, // 17: pb.Request.CmdType.copyOut:type_name -> pb.Request.CmdCopyOutFile
-	18, // 18: pb.Request.CmdType.copyOutCached:type_name -> pb.Request.CmdCopyOutFile
-	21, // 19: pb.Request.PipeMap.in:type_name -> pb.Request.PipeMap.PipeIndex
-	21, // 20: pb.Request.PipeMap.out:type_name -> pb.Request.PipeMap.PipeIndex
-	16, // 21: pb.Request.CmdType.CopyInEntry.value:type_name -> pb.Request.File
-	0,  // 22: pb.Response.FileError.type:type_name -> pb.Response.FileError.ErrorType
-	1,  // 23: pb.Response.Result.status:type_name -> pb.Response.Result.StatusType
-	24, // 24: pb.Response.Result.files:type_name -> pb.Response.Result.FilesEntry
-	25, // 25: pb.Response.Result.fileIDs:type_name -> pb.Response.Result.FileIDsEntry
-	22, // 26: pb.Response.Result.fileError:type_name -> pb.Response.FileError
-	5,  // 27: pb.Executor.Exec:input_type -> pb.Request
-	7,  // 28: pb.Executor.ExecStream:input_type -> pb.StreamRequest
-	29, // 29: pb.Executor.FileList:input_type -> google.protobuf.Empty
-	2,  // 30: pb.Executor.FileGet:input_type -> pb.FileID
-	3,  // 31: pb.Executor.FileAdd:input_type -> pb.FileContent
-	2,  // 32: pb.Executor.FileDelete:input_type -> pb.FileID
-	6,  // 33: pb.Executor.Exec:output_type -> pb.Response
-	8,  // 34: pb.Executor.ExecStream:output_type -> pb.StreamResponse
-	4,  // 35: pb.Executor.FileList:output_type -> pb.FileListType
-	3,  // 36: pb.Executor.FileGet:output_type -> pb.FileContent
-	2,  // 37: pb.Executor.FileAdd:output_type -> pb.FileID
-	29, // 38: pb.Executor.FileDelete:output_type -> google.protobuf.Empty
-	33, // [33:39] is the sub-list for method output_type
-	27, // [27:33] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	13, // 0: pb.FileListType.fileIDs:type_name -> pb.FileListType.FileIDsEntry
+	23, // 1: pb.Request.cmd:type_name -> pb.Request.CmdType
+	28, // 2: pb.Request.pipeMapping:type_name -> pb.Request.PipeMap
+	30, // 3: pb.Request.stages:type_name -> pb.Request.Stage
+	33, // 4: pb.Request.batch:type_name -> pb.Request.Batch
+	31, // 5: pb.Request.interactive:type_name -> pb.Request.Interactive
+	29, // 6: pb.Request.groupLimit:type_name -> pb.Request.GroupLimit
+	0,  // 7: pb.Request.groupPolicy:type_name -> pb.Request.GroupPolicy
+	39, // 8: pb.Response.results:type_name -> pb.Response.Result
+	9,  // 9: pb.StreamRequest.execRequest:type_name -> pb.Request
+	44, // 10: pb.StreamRequest.execInput:type_name -> pb.StreamRequest.Input
+	45, // 11: pb.StreamRequest.execResize:type_name -> pb.StreamRequest.Resize
+	10, // 12: pb.StreamResponse.execResponse:type_name -> pb.Response
+	46, // 13: pb.StreamResponse.execOutput:type_name -> pb.StreamResponse.Output
+	22, // 14: pb.Request.ArchiveFile.file:type_name -> pb.Request.File
+	14, // 15: pb.Request.File.local:type_name -> pb.Request.LocalFile
+	15, // 16: pb.Request.File.memory:type_name -> pb.Request.MemoryFile
+	16, // 17: pb.Request.File.cached:type_name -> pb.Request.CachedFile
+	17, // 18: pb.Request.File.pipe:type_name -> pb.Request.PipeCollector
+	18, // 19: pb.Request.File.streamIn:type_name -> pb.Request.StreamInput
+	19, // 20: pb.Request.File.streamOut:type_name -> pb.Request.StreamOutput
+	20, // 21: pb.Request.File.stage:type_name -> pb.Request.StageFile
+	21, // 22: pb.Request.File.archive:type_name -> pb.Request.ArchiveFile
+	22, // 23: pb.Request.CmdType.files:type_name -> pb.Request.File
+	34, // 24: pb.Request.CmdType.copyIn:type_name -> pb.Request.CmdType.CopyInEntry
+	26, // 25: pb.Request.CmdType.copyOut:type_name -> pb.Request.CmdCopyOutFile
+	26, // 26: pb.Request.CmdType.copyOutCached:type_name -> pb.Request.CmdCopyOutFile
+	26, // 27: pb.Request.CmdType.copyOutStage:type_name -> pb.Request.CmdCopyOutFile
+	25, // 28: pb.Request.CmdType.compare:type_name -> pb.Request.Compare
+	24, // 29: pb.Request.CmdType.checker:type_name -> pb.Request.Checker
+	23, // 30: pb.Request.Checker.cmd:type_name -> pb.Request.CmdType
+	22, // 31: pb.Request.Checker.input:type_name -> pb.Request.File
+	22, // 32: pb.Request.Checker.answer:type_name -> pb.Request.File
+	22, // 33: pb.Request.Compare.expected:type_name -> pb.Request.File
+	1,  // 34: pb.Request.Compare.mode:type_name -> pb.Request.Compare.CompareMode
+	27, // 35: pb.Request.CmdCopyOutFile.archive:type_name -> pb.Request.CopyOutArchive
+	35, // 36: pb.Request.PipeMap.in:type_name -> pb.Request.PipeMap.PipeIndex
+	35, // 37: pb.Request.PipeMap.out:type_name -> pb.Request.PipeMap.PipeIndex
+	35, // 38: pb.Request.PipeMap.fanOut:type_name -> pb.Request.PipeMap.PipeIndex
+	23, // 39: pb.Request.Stage.cmd:type_name -> pb.Request.CmdType
+	28, // 40: pb.Request.Stage.pipeMapping:type_name -> pb.Request.PipeMap
+	33, // 41: pb.Request.Stage.batch:type_name -> pb.Request.Batch
+	31, // 42: pb.Request.Stage.interactive:type_name -> pb.Request.Interactive
+	29, // 43: pb.Request.Stage.groupLimit:type_name -> pb.Request.GroupLimit
+	0,  // 44: pb.Request.Stage.groupPolicy:type_name -> pb.Request.GroupPolicy
+	23, // 45: pb.Request.Interactive.solution:type_name -> pb.Request.CmdType
+	23, // 46: pb.Request.Interactive.interactor:type_name -> pb.Request.CmdType
+	22, // 47: pb.Request.BatchCase.stdin:type_name -> pb.Request.File
+	22, // 48: pb.Request.BatchCase.expected:type_name -> pb.Request.File
+	22, // 49: pb.Request.BatchCase.answer:type_name -> pb.Request.File
+	23, // 50: pb.Request.Batch.cmd:type_name -> pb.Request.CmdType
+	32, // 51: pb.Request.Batch.cases:type_name -> pb.Request.BatchCase
+	22, // 52: pb.Request.CmdType.CopyInEntry.value:type_name -> pb.Request.File
+	2,  // 53: pb.Response.FileError.type:type_name -> pb.Response.FileError.ErrorType
+	3,  // 54: pb.Response.Result.status:type_name -> pb.Response.Result.StatusType
+	41, // 55: pb.Response.Result.files:type_name -> pb.Response.Result.FilesEntry
+	42, // 56: pb.Response.Result.fileIDs:type_name -> pb.Response.Result.FileIDsEntry
+	36, // 57: pb.Response.Result.fileError:type_name -> pb.Response.FileError
+	37, // 58: pb.Response.Result.usageTimeline:type_name -> pb.Response.UsageTimeline
+	40, // 59: pb.Response.Result.attempts:type_name -> pb.Response.Attempt
+	38, // 60: pb.Response.Result.copyOutDir:type_name -> pb.Response.CopyOutDirEntry
+	43, // 61: pb.Response.Result.outputSize:type_name -> pb.Response.Result.OutputSizeEntry
+	4,  // 62: pb.Response.Result.groupLimitExceeded:type_name -> pb.Response.Result.GroupLimitType
+	3,  // 63: pb.Response.Attempt.status:type_name -> pb.Response.Result.StatusType
+	9,  // 64: pb.Executor.Exec:input_type -> pb.Request
+	11, // 65: pb.Executor.ExecStream:input_type -> pb.StreamRequest
+	47, // 66: pb.Executor.FileList:input_type -> google.protobuf.Empty
+	5,  // 67: pb.Executor.FileGet:input_type -> pb.FileID
+	6,  // 68: pb.Executor.FileAdd:input_type -> pb.FileContent
+	5,  // 69: pb.Executor.FileDelete:input_type -> pb.FileID
+	47, // 70: pb.Executor.GetParallelism:input_type -> google.protobuf.Empty
+	8,  // 71: pb.Executor.SetParallelism:input_type -> pb.Parallelism
+	10, // 72: pb.Executor.Exec:output_type -> pb.Response
+	12, // 73: pb.Executor.ExecStream:output_type -> pb.StreamResponse
+	7,  // 74: pb.Executor.FileList:output_type -> pb.FileListType
+	6,  // 75: pb.Executor.FileGet:output_type -> pb.FileContent
+	5,  // 76: pb.Executor.FileAdd:output_type -> pb.FileID
+	47, // 77: pb.Executor.FileDelete:output_type -> google.protobuf.Empty
+	8,  // 78: pb.Executor.GetParallelism:output_type -> pb.Parallelism
+	8,  // 79: pb.Executor.SetParallelism:output_type -> pb.Parallelism
+	72, // [72:80] is the sub-list for method output_type
+	64, // [64:72] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_judge_proto_init() }
@@ -2179,7 +3907,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*Parallelism); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2191,7 +3919,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2203,7 +3931,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamRequest); i {
+			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2215,6 +3943,18 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamResponse); i {
 			case 0:
 				return &v.state
@@ -2226,7 +3966,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_LocalFile); i {
 			case 0:
 				return &v.state
@@ -2238,7 +3978,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_MemoryFile); i {
 			case 0:
 				return &v.state
@@ -2250,7 +3990,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_CachedFile); i {
 			case 0:
 				return &v.state
@@ -2262,7 +4002,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_PipeCollector); i {
 			case 0:
 				return &v.state
@@ -2274,7 +4014,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_StreamInput); i {
 			case 0:
 				return &v.state
@@ -2286,7 +4026,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_StreamOutput); i {
 			case 0:
 				return &v.state
@@ -2298,7 +4038,31 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_StageFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_ArchiveFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_File); i {
 			case 0:
 				return &v.state
@@ -2310,7 +4074,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_CmdType); i {
 			case 0:
 				return &v.state
@@ -2322,7 +4086,31 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Checker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Compare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_CmdCopyOutFile); i {
 			case 0:
 				return &v.state
@@ -2334,7 +4122,19 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_CopyOutArchive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_PipeMap); i {
 			case 0:
 				return &v.state
@@ -2346,7 +4146,67 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_GroupLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Stage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Interactive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_BatchCase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request_PipeMap_PipeIndex); i {
 			case 0:
 				return &v.state
@@ -2358,7 +4218,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response_FileError); i {
 			case 0:
 				return &v.state
@@ -2370,7 +4230,31 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_UsageTimeline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_CopyOutDirEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response_Result); i {
 			case 0:
 				return &v.state
@@ -2382,7 +4266,19 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_Attempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamRequest_Input); i {
 			case 0:
 				return &v.state
@@ -2394,7 +4290,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamRequest_Resize); i {
 			case 0:
 				return &v.state
@@ -2406,7 +4302,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamResponse_Output); i {
 			case 0:
 				return &v.state
@@ -2419,30 +4315,32 @@ func file_judge_proto_init() {
 			}
 		}
 	}
-	file_judge_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*StreamRequest_ExecRequest)(nil),
 		(*StreamRequest_ExecInput)(nil),
 		(*StreamRequest_ExecResize)(nil),
 	}
-	file_judge_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*StreamResponse_ExecResponse)(nil),
 		(*StreamResponse_ExecOutput)(nil),
 	}
-	file_judge_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*Request_File_Local)(nil),
 		(*Request_File_Memory)(nil),
 		(*Request_File_Cached)(nil),
 		(*Request_File_Pipe)(nil),
 		(*Request_File_StreamIn)(nil),
 		(*Request_File_StreamOut)(nil),
+		(*Request_File_Stage)(nil),
+		(*Request_File_Archive)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_judge_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   27,
+			NumEnums:      5,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
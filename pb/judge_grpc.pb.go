@@ -35,6 +35,11 @@ type ExecutorClient interface {
 	FileAdd(ctx context.Context, in *FileContent, opts ...grpc.CallOption) (*FileID, error)
 	// FileDelete deletes a file from the file store
 	FileDelete(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetParallelism returns the target and the current number of worker loops
+	GetParallelism(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Parallelism, error)
+	// SetParallelism resizes the number of worker loops (admin token only),
+	// extra loops exit after their running requests finish
+	SetParallelism(ctx context.Context, in *Parallelism, opts ...grpc.CallOption) (*Parallelism, error)
 }
 
 type executorClient struct {
@@ -121,6 +126,24 @@ func (c *executorClient) FileDelete(ctx context.Context, in *FileID, opts ...grp
 	return out, nil
 }
 
+func (c *executorClient) GetParallelism(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Parallelism, error) {
+	out := new(Parallelism)
+	err := c.cc.Invoke(ctx, "/pb.Executor/GetParallelism", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) SetParallelism(ctx context.Context, in *Parallelism, opts ...grpc.CallOption) (*Parallelism, error) {
+	out := new(Parallelism)
+	err := c.cc.Invoke(ctx, "/pb.Executor/SetParallelism", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 // All implementations must embed UnimplementedExecutorServer
 // for forward compatibility
@@ -141,6 +164,11 @@ type ExecutorServer interface {
 	FileAdd(context.Context, *FileContent) (*FileID, error)
 	// FileDelete deletes a file from the file store
 	FileDelete(context.Context, *FileID) (*emptypb.Empty, error)
+	// GetParallelism returns the target and the current number of worker loops
+	GetParallelism(context.Context, *emptypb.Empty) (*Parallelism, error)
+	// SetParallelism resizes the number of worker loops (admin token only),
+	// extra loops exit after their running requests finish
+	SetParallelism(context.Context, *Parallelism) (*Parallelism, error)
 	mustEmbedUnimplementedExecutorServer()
 }
 
@@ -166,6 +194,12 @@ func (UnimplementedExecutorServer) FileAdd(context.Context, *FileContent) (*File
 func (UnimplementedExecutorServer) FileDelete(context.Context, *FileID) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FileDelete not implemented")
 }
+func (UnimplementedExecutorServer) GetParallelism(context.Context, *emptypb.Empty) (*Parallelism, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParallelism not implemented")
+}
+func (UnimplementedExecutorServer) SetParallelism(context.Context, *Parallelism) (*Parallelism, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetParallelism not implemented")
+}
 func (UnimplementedExecutorServer) mustEmbedUnimplementedExecutorServer() {}
 
 // UnsafeExecutorServer may be embedded to opt out of forward compatibility for this service.
@@ -295,6 +329,42 @@ func _Executor_FileDelete_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_GetParallelism_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).GetParallelism(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Executor/GetParallelism",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).GetParallelism(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_SetParallelism_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Parallelism)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).SetParallelism(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Executor/SetParallelism",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).SetParallelism(ctx, req.(*Parallelism))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Executor_ServiceDesc is the grpc.ServiceDesc for Executor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -322,6 +392,14 @@ var Executor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FileDelete",
 			Handler:    _Executor_FileDelete_Handler,
 		},
+		{
+			MethodName: "GetParallelism",
+			Handler:    _Executor_GetParallelism_Handler,
+		},
+		{
+			MethodName: "SetParallelism",
+			Handler:    _Executor_SetParallelism_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// workDoBatch runs the cases one after another inside the same environment.
+// The copy in files are copied once before the first case and verified after
+// every case, the collectors are truncated before every case and the other
+// files created by the previous cases are kept
+func (w *worker) workDoBatch(ctx context.Context, b *Batch) (rt Response) {
+	env, err := w.envPool.Get()
+	if err != nil {
+		return Response{Results: []Result{{
+			Status: envexec.StatusInternalError,
+			Error:  fmt.Sprintf("failed to get environment %v", err),
+		}}}
+	}
+	defer w.envPool.Put(env)
+
+	var digests []envexec.CopyInDigest
+	rt.Results = make([]Result, 0, len(b.Cases))
+	for i, bc := range b.Cases {
+		if err := ctx.Err(); err != nil {
+			rt.Error = err
+			return
+		}
+		rc := b.Cmd
+		applyBatchCase(&rc, bc)
+		if i > 0 {
+			rc.CopyIn = nil
+		}
+
+		c, wait, err := w.prepareCmd(rc)
+		if err != nil {
+			rt.Error = err
+			return
+		}
+		c.Environment = env
+		c.CopyInDigests = digests
+
+		s := &envexec.Single{
+			Cmd:          c,
+			NewStoreFile: w.fs.New,
+		}
+		result, err := s.Run(ctx)
+		if err != nil {
+			rt.Error = err
+			return
+		}
+		digests = result.CopyInDigests
+		res := w.convertResult(ctx, result, rc, wait)
+		rt.Results = append(rt.Results, res)
+		if b.StopOnFailure && res.Status != envexec.StatusAccepted {
+			break
+		}
+	}
+	return
+}
+
+// applyBatchCase overrides the stdin, expected output, answer and limits of the cmd by the case
+func applyBatchCase(c *Cmd, bc BatchCase) {
+	if bc.Stdin != nil {
+		files := make([]CmdFile, 0, len(c.Files)+1)
+		files = append(files, bc.Stdin)
+		if len(c.Files) > 0 {
+			files = append(files, c.Files[1:]...)
+		}
+		c.Files = files
+	}
+	if bc.Expected != nil && c.Compare != nil {
+		cmp := *c.Compare
+		cmp.Expected = bc.Expected
+		c.Compare = &cmp
+	}
+	if bc.Answer != nil && c.Checker != nil {
+		ck := *c.Checker
+		ck.Answer = bc.Answer
+		c.Checker = &ck
+	}
+	if bc.CPULimit > 0 {
+		c.CPULimit = bc.CPULimit
+	}
+	if bc.ClockLimit > 0 {
+		c.ClockLimit = bc.ClockLimit
+	}
+	if bc.MemoryLimit > 0 {
+		c.MemoryLimit = bc.MemoryLimit
+	}
+}
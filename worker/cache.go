@@ -0,0 +1,397 @@
+package worker
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/filestore"
+)
+
+type cacheKey [sha256.Size]byte
+
+// resultCache stores results of single commands by the content hash of the
+// command, evicts the least recently used entries over the size or the age
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+	size    Size
+	maxSize Size
+	maxAge  time.Duration
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	result  Result
+	files   []cachedFile
+	size    Size
+	created time.Time
+}
+
+// cachedFile stores the content of a copy out file and where it goes
+type cachedFile struct {
+	name    string
+	kind    cachedFileKind
+	content []byte
+}
+
+type cachedFileKind int
+
+const (
+	cachedFileCopyOut cachedFileKind = iota
+	cachedFileCopyOutCached
+	cachedFileCopyOutStage
+)
+
+func newResultCache(maxSize Size, maxAge time.Duration) *resultCache {
+	return &resultCache{
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+		maxSize: maxSize,
+		maxAge:  maxAge,
+	}
+}
+
+func (c *resultCache) get(key cacheKey) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	ce := e.Value.(*cacheEntry)
+	if c.maxAge > 0 && time.Since(ce.created) > c.maxAge {
+		c.remove(e)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return ce, true
+}
+
+func (c *resultCache) put(ce *cacheEntry) {
+	if ce.size > c.maxSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[ce.key]; ok {
+		c.remove(e)
+	}
+	c.entries[ce.key] = c.lru.PushFront(ce)
+	c.size += ce.size
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *resultCache) remove(e *list.Element) {
+	ce := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, ce.key)
+	c.size -= ce.size
+}
+
+// cacheable statuses are determined by the program rather than the system,
+// resource limit verdicts depend on the load of the host and are run again
+func cacheableStatus(s envexec.Status) bool {
+	switch s {
+	case envexec.StatusInternalError, envexec.StatusFileError, envexec.StatusJudgementFailed,
+		envexec.StatusTimeLimitExceeded, envexec.StatusMemoryLimitExceeded, envexec.StatusOutputLimitExceeded:
+		return false
+	}
+	return true
+}
+
+// loadCache returns the cached result with the copy out files recreated
+func (w *worker) loadCache(key cacheKey) (Result, bool) {
+	ce, ok := w.cache.get(key)
+	if w.cacheObserver != nil {
+		w.cacheObserver(ok)
+	}
+	if !ok {
+		return Result{}, false
+	}
+	res, err := w.loadCacheEntry(ce)
+	if err != nil {
+		w.releaseResult(res)
+		return Result{}, false
+	}
+	return res, true
+}
+
+func (w *worker) loadCacheEntry(ce *cacheEntry) (Result, error) {
+	res := ce.result
+	res.Files = make(map[string]*os.File)
+	res.FileIDs = make(map[string]string)
+	res.stageFileIDs = make(map[string]string)
+	for _, cf := range ce.files {
+		f, err := w.fs.New()
+		if err != nil {
+			return res, err
+		}
+		if _, err := f.Write(cf.content); err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			return res, err
+		}
+		if cf.kind == cachedFileCopyOut {
+			res.Files[cf.name] = f
+			if _, err := f.Seek(0, 0); err != nil {
+				return res, err
+			}
+			continue
+		}
+		f.Close()
+		id, err := w.fs.Add(cf.name, f.Name())
+		if err != nil {
+			return res, err
+		}
+		if cf.kind == cachedFileCopyOutStage {
+			res.stageFileIDs[cf.name] = id
+		} else {
+			res.FileIDs[cf.name] = id
+		}
+	}
+	return res, nil
+}
+
+// releaseResult removes the copy out files of the result
+func (w *worker) releaseResult(res Result) {
+	for _, f := range res.Files {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	for _, id := range res.FileIDs {
+		w.fs.Remove(id)
+	}
+	for _, id := range res.stageFileIDs {
+		w.fs.Remove(id)
+	}
+}
+
+// storeCache stores the result with the content of its copy out files
+func (w *worker) storeCache(key cacheKey, res Result) {
+	if !cacheableStatus(res.Status) {
+		return
+	}
+	ce := &cacheEntry{
+		key:     key,
+		result:  res,
+		created: time.Now(),
+	}
+	ce.result.Files, ce.result.FileIDs, ce.result.stageFileIDs = nil, nil, nil
+
+	add := func(name string, kind cachedFileKind, r io.Reader) bool {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return false
+		}
+		ce.files = append(ce.files, cachedFile{name: name, kind: kind, content: b})
+		ce.size += Size(len(b))
+		return true
+	}
+	for name, f := range res.Files {
+		if _, err := f.Seek(0, 0); err != nil {
+			return
+		}
+		ok := add(name, cachedFileCopyOut, f)
+		if _, err := f.Seek(0, 0); err != nil || !ok {
+			return
+		}
+	}
+	for kind, ids := range map[cachedFileKind]map[string]string{
+		cachedFileCopyOutCached: res.FileIDs,
+		cachedFileCopyOutStage:  res.stageFileIDs,
+	} {
+		for name, id := range ids {
+			r, err := openStoreFile(w.fs, id)
+			if err != nil {
+				return
+			}
+			ok := add(name, kind, r)
+			r.Close()
+			if !ok {
+				return
+			}
+		}
+	}
+	w.cache.put(ce)
+}
+
+func openStoreFile(fs filestore.FileStore, id string) (io.ReadCloser, error) {
+	_, f := fs.Get(id)
+	if f == nil {
+		return nil, fmt.Errorf("file not exists with id %v", id)
+	}
+	return envexec.FileToReader(f)
+}
+
+// cacheKey returns the content hash of the cmd, false if the cmd is not cacheable
+func (w *worker) cacheKey(c Cmd) (cacheKey, bool) {
+	var key cacheKey
+	if w.cache == nil || c.NoCache || c.TTY || c.CopyOutDir != "" {
+		return key, false
+	}
+	h := &cacheHasher{Hash: sha256.New(), fs: w.fs}
+	h.cmd(c)
+	if h.err != nil {
+		return key, false
+	}
+	h.Sum(key[:0])
+	return key, true
+}
+
+// cacheHasher writes the fields into the hash with the length suffixed
+type cacheHasher struct {
+	hash.Hash
+	fs  filestore.FileStore
+	err error
+}
+
+func (h *cacheHasher) cmd(c Cmd) {
+	h.strings(c.Args)
+	h.strings(c.Env)
+	for _, v := range []uint64{
+		uint64(c.CPULimit), uint64(c.ClockLimit), uint64(c.MemoryLimit), uint64(c.StackLimit),
+		uint64(c.OutputLimit), uint64(c.IdleLimit), c.ProcLimit, c.OpenFileLimit, c.CPURateLimit, c.CopyOutMax,
+	} {
+		h.uint(v)
+	}
+	h.string(c.CPUSetLimit)
+	h.bool(c.StrictMemoryLimit)
+
+	h.uint(uint64(len(c.Files)))
+	for _, f := range c.Files {
+		h.file(f)
+	}
+	names := make([]string, 0, len(c.CopyIn))
+	for n := range c.CopyIn {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	h.uint(uint64(len(names)))
+	for _, n := range names {
+		h.string(n)
+		h.file(c.CopyIn[n])
+	}
+	for _, co := range [][]CmdCopyOutFile{c.CopyOut, c.CopyOutCached, c.CopyOutStage} {
+		h.uint(uint64(len(co)))
+		for _, f := range co {
+			h.string(f.Name)
+			h.bool(f.Optional)
+			h.bool(f.Archive != nil)
+			if f.Archive != nil {
+				h.uint(uint64(f.Archive.Format))
+				h.uint(uint64(len(f.Archive.Patterns)))
+				for _, p := range f.Archive.Patterns {
+					h.string(p)
+				}
+				h.uint(uint64(f.Archive.FileLimit))
+			}
+		}
+	}
+
+	h.bool(c.Compare != nil)
+	if c.Compare != nil {
+		h.uint(uint64(c.Compare.Mode))
+		h.string(fmt.Sprint(c.Compare.AbsTolerance, c.Compare.RelTolerance))
+		h.file(c.Compare.Expected)
+	}
+	h.bool(c.UsageTimeline)
+	h.bool(c.Checker != nil)
+	if c.Checker != nil {
+		h.cmd(c.Checker.Cmd)
+		h.file(c.Checker.Input)
+		h.file(c.Checker.Answer)
+	}
+}
+
+// file writes the content of the file, streams are not cacheable
+func (h *cacheHasher) file(f CmdFile) {
+	switch f := f.(type) {
+	case nil:
+		h.string("nil")
+	case *Collector:
+		h.string("collector")
+		h.string(f.Name)
+		h.uint(uint64(f.Max))
+		h.bool(f.Pipe)
+		h.uint(uint64(f.Tail))
+	case *MemoryFile:
+		h.string("content")
+		h.bytes(f.Content)
+	case *ArchiveFile:
+		h.string("archive")
+		h.uint(uint64(f.Format))
+		h.uint(uint64(f.Limit))
+		h.uint(uint64(f.InodeLimit))
+		h.file(f.Source)
+	case *CopyInFile:
+		h.string("copyin")
+		h.uint(uint64(f.Mode))
+		h.bool(f.VerifyUnchanged)
+		h.file(f.Source)
+	case *LocalFile, *CachedFile:
+		ef, err := f.EnvFile(h.fs)
+		if err != nil {
+			h.err = err
+			return
+		}
+		r, err := envexec.FileToReader(ef)
+		if err != nil {
+			h.err = err
+			return
+		}
+		defer r.Close()
+		h.string("content")
+		n, err := io.Copy(h.Hash, r)
+		if err != nil {
+			h.err = err
+			return
+		}
+		h.uint(uint64(n))
+	default:
+		h.err = fmt.Errorf("file is not cacheable: %v", f)
+	}
+}
+
+func (h *cacheHasher) strings(s []string) {
+	h.uint(uint64(len(s)))
+	for _, v := range s {
+		h.string(v)
+	}
+}
+
+func (h *cacheHasher) string(s string) {
+	h.bytes([]byte(s))
+}
+
+func (h *cacheHasher) bytes(b []byte) {
+	h.Write(b)
+	h.uint(uint64(len(b)))
+}
+
+func (h *cacheHasher) uint(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	h.Write(b[:])
+}
+
+func (h *cacheHasher) bool(v bool) {
+	if v {
+		h.uint(1)
+	} else {
+		h.uint(0)
+	}
+}
@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/filestore"
+)
+
+func TestCacheableStatus(t *testing.T) {
+	tests := []struct {
+		status    envexec.Status
+		cacheable bool
+	}{
+		{envexec.StatusAccepted, true},
+		{envexec.StatusWrongAnswer, true},
+		{envexec.StatusNonzeroExitStatus, true},
+		{envexec.StatusSignalled, true},
+		{envexec.StatusTimeLimitExceeded, false},
+		{envexec.StatusMemoryLimitExceeded, false},
+		{envexec.StatusOutputLimitExceeded, false},
+		{envexec.StatusInternalError, false},
+		{envexec.StatusFileError, false},
+		{envexec.StatusJudgementFailed, false},
+	}
+	for _, tc := range tests {
+		if got := cacheableStatus(tc.status); got != tc.cacheable {
+			t.Errorf("%v: cacheable = %v, expected %v", tc.status, got, tc.cacheable)
+		}
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	dir := t.TempDir()
+	local := filepath.Join(dir, "local")
+	if err := os.WriteFile(local, []byte("local"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := New(Config{
+		FileStore:       filestore.NewFileLocalStore(dir),
+		ResultCacheSize: 1 << 20,
+	}).(*worker)
+	defer w.Shutdown()
+
+	base := func() Cmd {
+		return Cmd{
+			Args:     []string{"a", "b"},
+			Env:      []string{"PATH=/bin"},
+			Files:    []CmdFile{&MemoryFile{Content: []byte("in")}, &Collector{Name: "stdout", Max: 1024}},
+			CPULimit: time.Second,
+			CopyIn: map[string]CmdFile{
+				"a": &LocalFile{Src: local},
+				"b": &CopyInFile{Source: &MemoryFile{Content: []byte("b")}},
+			},
+			CopyOut: []CmdCopyOutFile{{Name: "out"}},
+			Compare: &Compare{Expected: &MemoryFile{Content: []byte("ok")}},
+		}
+	}
+	key, ok := w.cacheKey(base())
+	if !ok {
+		t.Fatal("expected cmd cacheable")
+	}
+	if k, _ := w.cacheKey(base()); k != key {
+		t.Error("expected the same key for the same cmd")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Cmd)
+	}{
+		{"args", func(c *Cmd) { c.Args = []string{"a", "c"} }},
+		{"args boundary", func(c *Cmd) { c.Args = []string{"ab"} }},
+		{"env", func(c *Cmd) { c.Env = nil }},
+		{"cpu limit", func(c *Cmd) { c.CPULimit = 2 * time.Second }},
+		{"clock limit", func(c *Cmd) { c.ClockLimit = time.Second }},
+		{"memory limit", func(c *Cmd) { c.MemoryLimit = 1 }},
+		{"stack limit", func(c *Cmd) { c.StackLimit = 1 }},
+		{"output limit", func(c *Cmd) { c.OutputLimit = 1 }},
+		{"idle limit", func(c *Cmd) { c.IdleLimit = time.Second }},
+		{"proc limit", func(c *Cmd) { c.ProcLimit = 1 }},
+		{"open file limit", func(c *Cmd) { c.OpenFileLimit = 1 }},
+		{"cpu rate limit", func(c *Cmd) { c.CPURateLimit = 1 }},
+		{"cpu set limit", func(c *Cmd) { c.CPUSetLimit = "0" }},
+		{"strict memory limit", func(c *Cmd) { c.StrictMemoryLimit = true }},
+		{"copy out max", func(c *Cmd) { c.CopyOutMax = 1 }},
+		{"stdin content", func(c *Cmd) { c.Files[0] = &MemoryFile{Content: []byte("in2")} }},
+		{"collector max", func(c *Cmd) { c.Files[1] = &Collector{Name: "stdout", Max: 1} }},
+		{"collector pipe", func(c *Cmd) { c.Files[1] = &Collector{Name: "stdout", Max: 1024, Pipe: true} }},
+		{"files count", func(c *Cmd) { c.Files = append(c.Files, nil) }},
+		{"copy in name", func(c *Cmd) { c.CopyIn["c"] = c.CopyIn["a"]; delete(c.CopyIn, "a") }},
+		{"copy in content", func(c *Cmd) { c.CopyIn["a"] = &MemoryFile{Content: []byte("other")} }},
+		{"copy in mode", func(c *Cmd) { c.CopyIn["b"] = &CopyInFile{Source: &MemoryFile{Content: []byte("b")}, Mode: 0755} }},
+		{"copy in verify", func(c *Cmd) {
+			c.CopyIn["b"] = &CopyInFile{Source: &MemoryFile{Content: []byte("b")}, VerifyUnchanged: true}
+		}},
+		{"copy out", func(c *Cmd) { c.CopyOut = nil }},
+		{"copy out optional", func(c *Cmd) { c.CopyOut = []CmdCopyOutFile{{Name: "out", Optional: true}} }},
+		{"copy out cached", func(c *Cmd) { c.CopyOutCached, c.CopyOut = c.CopyOut, nil }},
+		{"copy out stage", func(c *Cmd) { c.CopyOutStage, c.CopyOut = c.CopyOut, nil }},
+		{"copy out archive", func(c *Cmd) {
+			c.CopyOut = []CmdCopyOutFile{{Name: "out", Archive: &envexec.CopyOutArchive{}}}
+		}},
+		{"compare expected", func(c *Cmd) { c.Compare = &Compare{Expected: &MemoryFile{Content: []byte("ok2")}} }},
+		{"compare mode", func(c *Cmd) { c.Compare.Mode = 1 }},
+		{"compare tolerance", func(c *Cmd) { c.Compare.AbsTolerance = 1e-6 }},
+		{"no compare", func(c *Cmd) { c.Compare = nil }},
+		{"usage timeline", func(c *Cmd) { c.UsageTimeline = true }},
+		{"checker", func(c *Cmd) { c.Checker = &Checker{Cmd: Cmd{Args: []string{"chk"}}} }},
+	}
+	keys := map[cacheKey]string{key: "base"}
+	for _, tc := range tests {
+		c := base()
+		tc.modify(&c)
+		k, ok := w.cacheKey(c)
+		if !ok {
+			t.Errorf("%s: expected cmd cacheable", tc.name)
+			continue
+		}
+		if n, ok := keys[k]; ok {
+			t.Errorf("%s: same key as %s", tc.name, n)
+		}
+		keys[k] = tc.name
+	}
+
+	// local file content is hashed rather than the path
+	if err := os.WriteFile(local, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := w.cacheKey(base()); k == key {
+		t.Error("expected different key for changed local file content")
+	}
+
+	for name, modify := range map[string]func(c *Cmd){
+		"no cache":     func(c *Cmd) { c.NoCache = true },
+		"tty":          func(c *Cmd) { c.TTY = true },
+		"copy out dir": func(c *Cmd) { c.CopyOutDir = "dir" },
+		"stage file":   func(c *Cmd) { c.CopyIn["s"] = &StageFile{Name: "s"} },
+		"missing file": func(c *Cmd) { c.CopyIn["a"] = &LocalFile{Src: filepath.Join(dir, "missing")} },
+	} {
+		c := base()
+		modify(&c)
+		if _, ok := w.cacheKey(c); ok {
+			t.Errorf("%s: expected cmd not cacheable", name)
+		}
+	}
+}
+
+func TestResultCacheEviction(t *testing.T) {
+	entry := func(i byte, size Size) *cacheEntry {
+		return &cacheEntry{key: cacheKey{i}, size: size, created: time.Now()}
+	}
+	c := newResultCache(10, 0)
+	c.put(entry(1, 4))
+	c.put(entry(2, 4))
+	// access 1 so that 2 is the least recently used
+	if _, ok := c.get(cacheKey{1}); !ok {
+		t.Fatal("expected entry 1 cached")
+	}
+	c.put(entry(3, 4))
+	if _, ok := c.get(cacheKey{2}); ok {
+		t.Error("expected the least recently used entry 2 evicted")
+	}
+	for _, i := range []byte{1, 3} {
+		if _, ok := c.get(cacheKey{i}); !ok {
+			t.Errorf("expected entry %d cached", i)
+		}
+	}
+	if c.size != 8 {
+		t.Errorf("expected size 8, got %d", c.size)
+	}
+
+	// entry larger than the cache is not stored
+	c.put(entry(4, 11))
+	if _, ok := c.get(cacheKey{4}); ok {
+		t.Error("expected entry over the max size not cached")
+	}
+
+	// replacing the same key accounts the size once
+	c.put(entry(3, 2))
+	if c.size != 6 || c.lru.Len() != 2 {
+		t.Errorf("expected size 6 with 2 entries, got %d with %d", c.size, c.lru.Len())
+	}
+
+	// expired entry is removed on get
+	c = newResultCache(10, time.Minute)
+	old := entry(1, 1)
+	old.created = time.Now().Add(-2 * time.Minute)
+	c.put(old)
+	c.put(entry(2, 1))
+	if _, ok := c.get(cacheKey{1}); ok {
+		t.Error("expected expired entry evicted")
+	}
+	if _, ok := c.get(cacheKey{2}); !ok {
+		t.Error("expected entry 2 cached")
+	}
+	if c.size != 1 || c.lru.Len() != 1 {
+		t.Errorf("expected size 1 with 1 entry, got %d with %d", c.size, c.lru.Len())
+	}
+}
@@ -0,0 +1,198 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// Checker defines the special judge program to run after the cmd accepted.
+// It runs in its own environment with arguments `input output answer`
+// appended (testlib convention) and the files copied in with these names
+type Checker struct {
+	Cmd    Cmd     // Cmd defines the checker program, its files are ignored
+	Input  CmdFile // Input defaults to the stdin of the cmd (files[0])
+	Answer CmdFile
+}
+
+// file names of the checker copy in files
+const (
+	checkerInput  = "input"
+	checkerOutput = "output"
+	checkerAnswer = "answer"
+)
+
+// checker exit codes (testlib convention), others are judgement failed
+const (
+	checkerWrongAnswer       = 1
+	checkerPresentationError = 2
+	checkerPoints            = 7
+)
+
+// max size of the checker stdout and stderr to be collected
+const checkerOutputMax Size = 4 << 10
+
+// runChecker runs the checker with the collected stdout of the cmd and
+// updates the status, score and the checker message of the result
+func (w *worker) runChecker(ctx context.Context, res *Result, result envexec.Result, cmd Cmd) {
+	var name string
+	if len(cmd.Files) > 1 {
+		if c, ok := cmd.Files[1].(*Collector); ok {
+			name = c.Name
+		}
+	}
+	out, ok := result.Files[name]
+	if !ok {
+		res.Status = envexec.StatusJudgementFailed
+		res.Error = "checker: stdout is not collected"
+		return
+	}
+
+	input := cmd.Checker.Input
+	if input == nil {
+		input = checkerDefaultInput(cmd)
+	}
+	answer := cmd.Checker.Answer
+	if answer == nil {
+		answer = &MemoryFile{}
+	}
+
+	cc := cmd.Checker.Cmd
+	cc.Args = append(append([]string{}, cc.Args...), checkerInput, checkerOutput, checkerAnswer)
+	cc.Files = []CmdFile{
+		nil,
+		&Collector{Name: "stdout", Max: checkerOutputMax},
+		&Collector{Name: "stderr", Max: checkerOutputMax},
+	}
+	cc.CopyIn = make(map[string]CmdFile, len(cmd.Checker.Cmd.CopyIn)+3)
+	for k, v := range cmd.Checker.Cmd.CopyIn {
+		cc.CopyIn[k] = v
+	}
+	cc.CopyIn[checkerInput] = input
+	cc.CopyIn[checkerOutput] = &LocalFile{Src: out.Name()}
+	cc.CopyIn[checkerAnswer] = answer
+
+	cr, err := w.runCheckerCmd(ctx, cc)
+	if err != nil {
+		res.Status = envexec.StatusJudgementFailed
+		res.Error = fmt.Sprintf("checker: %v", err)
+		return
+	}
+	stdout, stderr := cr.Files["stdout"], cr.Files["stderr"]
+	res.CheckerMessage = string(bytes.TrimSpace(stderr))
+
+	switch {
+	case cr.Status == envexec.StatusAccepted:
+		res.Status = envexec.StatusAccepted
+
+	case cr.Status != envexec.StatusNonzeroExitStatus:
+		res.Status = envexec.StatusJudgementFailed
+		res.Error = fmt.Sprintf("checker: %v %v", cr.Status, cr.Error)
+
+	case cr.ExitStatus == checkerWrongAnswer || cr.ExitStatus == checkerPresentationError:
+		res.Status = envexec.StatusWrongAnswer
+
+	case cr.ExitStatus == checkerPoints:
+		score, err := parseCheckerScore(stdout)
+		if err != nil {
+			res.Status = envexec.StatusJudgementFailed
+			res.Error = fmt.Sprintf("checker: invalid score: %v", err)
+			return
+		}
+		res.Status = envexec.StatusPartiallyCorrect
+		res.Score = score
+
+	default:
+		res.Status = envexec.StatusJudgementFailed
+		res.Error = fmt.Sprintf("checker: exit with %d", cr.ExitStatus)
+	}
+}
+
+// checkerResult defines the result of the checker with the collected outputs
+type checkerResult struct {
+	Status     envexec.Status
+	ExitStatus int
+	Error      string
+	Files      map[string][]byte
+}
+
+func (w *worker) runCheckerCmd(ctx context.Context, rc Cmd) (*checkerResult, error) {
+	c, _, err := w.prepareCmd(rc)
+	if err != nil {
+		return nil, err
+	}
+	env, err := w.envPool.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment %v", err)
+	}
+	defer w.envPool.Put(env)
+	c.Environment = env
+
+	s := &envexec.Single{
+		Cmd:          c,
+		NewStoreFile: w.fs.New,
+	}
+	result, err := s.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rt := &checkerResult{
+		Status:     result.Status,
+		ExitStatus: result.ExitStatus,
+		Error:      result.Error,
+		Files:      make(map[string][]byte),
+	}
+	for name, f := range result.Files {
+		b, ferr := readStoreFile(f)
+		if ferr != nil {
+			err = ferr
+		}
+		rt.Files[name] = b
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// readStoreFile reads the whole content of the file created by the file store
+// and removes it
+func readStoreFile(f *os.File) ([]byte, error) {
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
+
+// checkerDefaultInput returns the stdin of the cmd if it can be read again
+func checkerDefaultInput(cmd Cmd) CmdFile {
+	if len(cmd.Files) > 0 {
+		switch f := cmd.Files[0].(type) {
+		case *LocalFile, *MemoryFile, *CachedFile:
+			return f
+		}
+	}
+	return &MemoryFile{}
+}
+
+// parseCheckerScore parses the first token of the checker stdout as score,
+// the optional `points` prefix written by testlib is skipped
+func parseCheckerScore(b []byte) (float64, error) {
+	fields := bytes.Fields(b)
+	if len(fields) > 0 && string(fields[0]) == "points" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty output")
+	}
+	return strconv.ParseFloat(string(fields[0]), 64)
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+var checkerTestPrograms = map[string]testProgram{
+	// checker exits with args[1] and writes args[2] to stdout after checking
+	// the arguments and the copied in files
+	"checker": func(ctx context.Context, dir string, args []string, files []*os.File) int {
+		if len(args) != 6 || args[3] != checkerInput || args[4] != checkerOutput || args[5] != checkerAnswer {
+			return 3
+		}
+		out, err := os.ReadFile(filepath.Join(dir, checkerOutput))
+		if err != nil || string(out) != "output" {
+			return 3
+		}
+		files[1].WriteString(args[2])
+		files[2].WriteString(" message \n")
+		code, _ := strconv.Atoi(args[1])
+		return code
+	},
+}
+
+func TestRunChecker(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		stdout string
+		status envexec.Status
+		score  float64
+		err    string
+	}{
+		{"accepted", "0", "", envexec.StatusAccepted, 0, ""},
+		{"wrong answer", "1", "", envexec.StatusWrongAnswer, 0, ""},
+		{"presentation error", "2", "", envexec.StatusWrongAnswer, 0, ""},
+		{"points", "7", "points 0.5\n", envexec.StatusPartiallyCorrect, 0.5, ""},
+		{"invalid points", "7", "half", envexec.StatusJudgementFailed, 0, "invalid score"},
+		{"fail", "3", "", envexec.StatusJudgementFailed, 0, "exit with 3"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newTestWorker(t, checkerTestPrograms)
+			out := filepath.Join(t.TempDir(), "stdout")
+			if err := os.WriteFile(out, []byte("output"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			cmd := Cmd{
+				Files:   []CmdFile{nil, &Collector{Name: "stdout", Max: 1024}},
+				Checker: &Checker{Cmd: testCmd("checker", tc.code, tc.stdout)},
+			}
+			res := Result{Status: envexec.StatusAccepted}
+			w.runChecker(context.Background(), &res, envexec.Result{Files: map[string]*os.File{"stdout": f}}, cmd)
+			if res.Status != tc.status {
+				t.Fatalf("expected status %v, got %v: %s", tc.status, res.Status, res.Error)
+			}
+			if res.Score != tc.score {
+				t.Errorf("expected score %v, got %v", tc.score, res.Score)
+			}
+			if !strings.Contains(res.Error, tc.err) {
+				t.Errorf("expected error %q, got %q", tc.err, res.Error)
+			}
+			if res.CheckerMessage != "message" {
+				t.Errorf("expected checker message %q, got %q", "message", res.CheckerMessage)
+			}
+		})
+	}
+}
+
+func TestRunCheckerNoStdout(t *testing.T) {
+	w := newTestWorker(t, checkerTestPrograms)
+	cmd := Cmd{Checker: &Checker{Cmd: testCmd("checker", "0", "")}}
+	res := Result{Status: envexec.StatusAccepted}
+	w.runChecker(context.Background(), &res, envexec.Result{}, cmd)
+	if res.Status != envexec.StatusJudgementFailed {
+		t.Errorf("expected status %v, got %v", envexec.StatusJudgementFailed, res.Status)
+	}
+}
@@ -0,0 +1,28 @@
+package worker
+
+import "testing"
+
+func TestParseCheckerScore(t *testing.T) {
+	tests := []struct {
+		out   string
+		score float64
+		ok    bool
+	}{
+		{"0.5", 0.5, true},
+		{"points 0.25\n", 0.25, true},
+		{"  1 extra message", 1, true},
+		{"points", 0, false},
+		{"", 0, false},
+		{"ok", 0, false},
+	}
+	for _, tc := range tests {
+		score, err := parseCheckerScore([]byte(tc.out))
+		if (err == nil) != tc.ok {
+			t.Errorf("%q: error %v, expected ok = %v", tc.out, err, tc.ok)
+			continue
+		}
+		if score != tc.score {
+			t.Errorf("%q: score %v, expected %v", tc.out, score, tc.score)
+		}
+	}
+}
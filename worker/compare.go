@@ -0,0 +1,285 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// CompareMode defines how the output is compared with the expected output
+type CompareMode int
+
+// Defines compare modes
+const (
+	CompareExact               CompareMode = iota // byte by byte
+	CompareIgnoreTrailingSpace                    // ignore trailing spaces of lines and trailing empty lines
+	CompareToken                                  // tokens separated by spaces
+	CompareFloat                                  // tokens, numbers are compared with tolerance
+)
+
+var compareModeString = []string{
+	"exact",
+	"ignoreTrailingSpace",
+	"token",
+	"float",
+}
+
+func (m CompareMode) String() string {
+	if m >= 0 && int(m) < len(compareModeString) {
+		return compareModeString[m]
+	}
+	return "unknown"
+}
+
+// StringToCompareMode converts the mode name into CompareMode
+func StringToCompareMode(s string) (CompareMode, error) {
+	if s == "" {
+		return CompareExact, nil
+	}
+	for i, n := range compareModeString {
+		if n == s {
+			return CompareMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid compare mode %q", s)
+}
+
+// Compare defines the expected output of the collected stdout (files[1])
+type Compare struct {
+	Expected CmdFile
+	Mode     CompareMode
+
+	// AbsTolerance and RelTolerance defines the tolerance for float mode,
+	// numbers are equal if either of the tolerance is satisfied
+	AbsTolerance float64
+	RelTolerance float64
+}
+
+// max length of the token shown in the diff
+const maxDiffTokenLen = 32
+
+// compareOutput compares the collected stdout with the expected output,
+// returns the diff summary of the first mismatch, empty if matched
+func (w *worker) compareOutput(result envexec.Result, cmd Cmd) (string, error) {
+	var name string
+	if len(cmd.Files) > 1 {
+		if c, ok := cmd.Files[1].(*Collector); ok {
+			name = c.Name
+		}
+	}
+	out, ok := result.Files[name]
+	if !ok {
+		return "", fmt.Errorf("stdout is not collected")
+	}
+	if cmd.Compare.Expected == nil {
+		return "", fmt.Errorf("expected output is not specified")
+	}
+	ef, err := cmd.Compare.Expected.EnvFile(w.fs)
+	if err != nil {
+		return "", err
+	}
+	expected, err := envexec.FileToReader(ef)
+	if err != nil {
+		return "", err
+	}
+	defer expected.Close()
+
+	if _, err := out.Seek(0, 0); err != nil {
+		return "", err
+	}
+	diff, err := compare(cmd.Compare, bufio.NewReader(out), bufio.NewReader(expected))
+	if err != nil {
+		return "", err
+	}
+	// rewind for the later readers
+	if _, err := out.Seek(0, 0); err != nil {
+		return "", err
+	}
+	return diff, nil
+}
+
+// compare returns the diff summary of the first mismatch, empty if matched
+func compare(c *Compare, out, expected *bufio.Reader) (string, error) {
+	switch c.Mode {
+	case CompareExact:
+		return compareLines(out, expected, false)
+	case CompareIgnoreTrailingSpace:
+		return compareLines(out, expected, true)
+	case CompareToken:
+		return compareTokens(out, expected, bytes.Equal)
+	case CompareFloat:
+		return compareTokens(out, expected, func(a, b []byte) bool {
+			return floatEqual(a, b, c.AbsTolerance, c.RelTolerance)
+		})
+	default:
+		return "", fmt.Errorf("invalid compare mode %d", c.Mode)
+	}
+}
+
+func compareLines(out, expected *bufio.Reader, trim bool) (string, error) {
+	for line := 1; ; line++ {
+		a, errA := out.ReadBytes('\n')
+		if errA != nil && errA != io.EOF {
+			return "", errA
+		}
+		b, errB := expected.ReadBytes('\n')
+		if errB != nil && errB != io.EOF {
+			return "", errB
+		}
+		if trim {
+			a, b = trimLine(a), trimLine(b)
+			// trailing empty lines are ignored
+			if errA == io.EOF && len(a) == 0 && errB != io.EOF {
+				return remainingLines(expected, b, line, false)
+			}
+			if errB == io.EOF && len(b) == 0 && errA != io.EOF {
+				return remainingLines(out, a, line, true)
+			}
+		}
+		if !bytes.Equal(a, b) {
+			col := commonPrefix(a, b)
+			return diffString(line, col+1, b[col:], a[col:]), nil
+		}
+		if errA == io.EOF && errB == io.EOF {
+			return "", nil
+		}
+	}
+}
+
+// remainingLines checks the rest of r contains only empty lines
+func remainingLines(r *bufio.Reader, cur []byte, line int, isOut bool) (string, error) {
+	for {
+		if len(cur) > 0 {
+			if isOut {
+				return diffString(line, 1, nil, cur), nil
+			}
+			return diffString(line, 1, cur, nil), nil
+		}
+		b, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		cur = trimLine(b)
+		if err == io.EOF && len(cur) == 0 {
+			return "", nil
+		}
+		line++
+	}
+}
+
+func compareTokens(out, expected *bufio.Reader, equal func(a, b []byte) bool) (string, error) {
+	to, te := &tokenReader{r: out, line: 1}, &tokenReader{r: expected, line: 1}
+	for {
+		a, line, col, err := to.next()
+		if err != nil {
+			return "", err
+		}
+		b, _, _, err := te.next()
+		if err != nil {
+			return "", err
+		}
+		if a == nil && b == nil {
+			return "", nil
+		}
+		if a == nil || b == nil || !equal(a, b) {
+			return diffString(line, col, b, a), nil
+		}
+	}
+}
+
+// tokenReader reads tokens separated by spaces and tracks the position
+type tokenReader struct {
+	r    *bufio.Reader
+	line int
+	col  int
+}
+
+// next returns the next token with its position, nil token for EOF
+func (t *tokenReader) next() ([]byte, int, int, error) {
+	var (
+		tok       []byte
+		line, col int
+	)
+	for {
+		c, err := t.r.ReadByte()
+		if err == io.EOF {
+			if tok == nil {
+				return nil, t.line, t.col + 1, nil
+			}
+			return tok, line, col, nil
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if isSpace(c) {
+			if tok != nil {
+				t.r.UnreadByte()
+				return tok, line, col, nil
+			}
+			if c == '\n' {
+				t.line++
+				t.col = 0
+			} else {
+				t.col++
+			}
+			continue
+		}
+		t.col++
+		if tok == nil {
+			line, col = t.line, t.col
+		}
+		tok = append(tok, c)
+	}
+}
+
+func floatEqual(a, b []byte, abs, rel float64) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	fa, errA := strconv.ParseFloat(string(a), 64)
+	fb, errB := strconv.ParseFloat(string(b), 64)
+	if errA != nil || errB != nil || math.IsNaN(fa) || math.IsNaN(fb) {
+		return false
+	}
+	d := math.Abs(fa - fb)
+	return d <= abs || d <= rel*math.Abs(fb)
+}
+
+func trimLine(b []byte) []byte {
+	return bytes.TrimRight(b, " \t\r\n\v\f")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f'
+}
+
+func commonPrefix(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func diffString(line, col int, expected, found []byte) string {
+	return fmt.Sprintf("line %d column %d: expected %s, found %s", line, col, diffToken(expected), diffToken(found))
+}
+
+// diffToken returns the quoted token until the next space, EOF if empty
+func diffToken(b []byte) string {
+	if len(b) == 0 {
+		return "EOF"
+	}
+	if i := bytes.IndexFunc(b, func(r rune) bool { return r < 0x80 && isSpace(byte(r)) }); i > 0 {
+		b = b[:i]
+	}
+	if len(b) > maxDiffTokenLen {
+		return strconv.Quote(string(b[:maxDiffTokenLen])) + "..."
+	}
+	return strconv.Quote(string(b))
+}
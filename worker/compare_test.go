@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Compare
+		out      string
+		expected string
+		diff     string
+	}{
+		{"exact", Compare{Mode: CompareExact}, "1 2\n3\n", "1 2\n3\n", ""},
+		{"exact trailing space", Compare{Mode: CompareExact}, "1 2 \n", "1 2\n", `line 1 column 4: expected "\n", found " \n"`},
+		{"exact missing newline", Compare{Mode: CompareExact}, "1", "1\n", `line 1 column 2: expected "\n", found EOF`},
+		{"exact mismatch", Compare{Mode: CompareExact}, "1\n2\n", "1\n3\n", `line 2 column 1: expected "3", found "2"`},
+
+		{"trailing space", Compare{Mode: CompareIgnoreTrailingSpace}, "1 2 \t\r\n3", "1 2\n3\n", ""},
+		{"trailing empty lines out", Compare{Mode: CompareIgnoreTrailingSpace}, "1\n\n  \n\n", "1\n", ""},
+		{"trailing empty lines expected", Compare{Mode: CompareIgnoreTrailingSpace}, "1", "1\n\n\n", ""},
+		{"trailing leading space", Compare{Mode: CompareIgnoreTrailingSpace}, " 1\n", "1\n", `line 1 column 1: expected "1", found " 1"`},
+		{"trailing extra line", Compare{Mode: CompareIgnoreTrailingSpace}, "1\n\n2\n", "1\n", `line 3 column 1: expected EOF, found "2"`},
+		{"trailing missing line", Compare{Mode: CompareIgnoreTrailingSpace}, "1\n", "1\n\n2\n", `line 3 column 1: expected "2", found EOF`},
+
+		{"token", Compare{Mode: CompareToken}, "1  2\n\n3", "1 2 3\n", ""},
+		{"token mismatch", Compare{Mode: CompareToken}, "1\n 4", "1 2\n", `line 2 column 2: expected "2", found "4"`},
+		{"token missing", Compare{Mode: CompareToken}, "1", "1 2", `line 1 column 2: expected "2", found EOF`},
+		{"token extra", Compare{Mode: CompareToken}, "1 2", "1", `line 1 column 3: expected EOF, found "2"`},
+
+		{"float abs", Compare{Mode: CompareFloat, AbsTolerance: 1e-6}, "0.3333333", "0.333333333", ""},
+		{"float abs exceeded", Compare{Mode: CompareFloat, AbsTolerance: 1e-6}, "0.333", "0.333333", `line 1 column 1: expected "0.333333", found "0.333"`},
+		{"float rel", Compare{Mode: CompareFloat, RelTolerance: 1e-6}, "1000000.5", "1000000", ""},
+		{"float rel exceeded", Compare{Mode: CompareFloat, RelTolerance: 1e-6}, "1000002", "1000000", `line 1 column 1: expected "1000000", found "1000002"`},
+		{"float exponent", Compare{Mode: CompareFloat, AbsTolerance: 1e-9}, "1e3", "1000.0", ""},
+		{"float token", Compare{Mode: CompareFloat, AbsTolerance: 1e-6}, "YES 1.0", "YES 1", ""},
+		{"float word", Compare{Mode: CompareFloat, AbsTolerance: 1}, "yes", "YES", `line 1 column 1: expected "YES", found "yes"`},
+		{"float nan", Compare{Mode: CompareFloat, AbsTolerance: 1}, "nan", "NaN", `line 1 column 1: expected "NaN", found "nan"`},
+		{"float no tolerance", Compare{Mode: CompareFloat}, "1.0", "1", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := compare(&tc.c, bufio.NewReader(strings.NewReader(tc.out)), bufio.NewReader(strings.NewReader(tc.expected)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != tc.diff {
+				t.Errorf("diff = %q, expected %q", diff, tc.diff)
+			}
+		})
+	}
+}
+
+func TestCompareLongToken(t *testing.T) {
+	long := strings.Repeat("a", maxDiffTokenLen+1)
+	diff, err := compare(&Compare{Mode: CompareToken}, bufio.NewReader(strings.NewReader(long)), bufio.NewReader(strings.NewReader("b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `line 1 column 1: expected "b", found "` + long[:maxDiffTokenLen] + `"...`; diff != expected {
+		t.Errorf("diff = %q, expected %q", diff, expected)
+	}
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/filestore"
+	"github.com/criyle/go-sandbox/runner"
+	"golang.org/x/sys/unix"
+)
+
+// testProgram runs in place of the process named by args[0] with the fds of
+// the process and the work dir, it returns the exit status
+type testProgram func(ctx context.Context, dir string, args []string, files []*os.File) int
+
+// testEnvPool creates testEnv running the programs for each Get
+type testEnvPool struct {
+	t        *testing.T
+	programs map[string]testProgram
+}
+
+func (p *testEnvPool) Get() (envexec.Environment, error) {
+	dir := p.t.TempDir()
+	wd, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	p.t.Cleanup(func() { wd.Close() })
+	return &testEnv{dir: dir, wd: wd, programs: p.programs}, nil
+}
+
+func (p *testEnvPool) Put(envexec.Environment) {}
+
+// testEnv implements Environment inside a temporary directory and runs the
+// programs as goroutines
+type testEnv struct {
+	dir      string
+	wd       *os.File
+	programs map[string]testProgram
+}
+
+func (e *testEnv) Execve(ctx context.Context, param envexec.ExecveParam) (envexec.Process, error) {
+	prog, ok := e.programs[param.Args[0]]
+	if !ok {
+		return nil, errors.New("program not found")
+	}
+	// the fds are closed once Execve returns
+	files := make([]*os.File, 0, len(param.Files))
+	for _, fd := range param.Files {
+		if fd == ^uintptr(0) {
+			files = append(files, nil)
+			continue
+		}
+		nfd, err := unix.Dup(int(fd))
+		if err != nil {
+			closeTestFiles(files)
+			return nil, err
+		}
+		files = append(files, os.NewFile(uintptr(nfd), ""))
+	}
+	p := &testProcess{done: make(chan struct{})}
+	start := time.Now()
+	go func() {
+		defer close(p.done)
+		status := prog(ctx, e.dir, param.Args, files)
+		closeTestFiles(files)
+		p.result = runner.Result{Status: runner.StatusNormal, ExitStatus: status, RunningTime: time.Since(start)}
+		if ctx.Err() != nil {
+			p.result.Status = runner.StatusSignalled
+			p.result.ExitStatus = int(unix.SIGKILL)
+		} else if status != 0 {
+			p.result.Status = runner.StatusNonzeroExitStatus
+		}
+	}()
+	return p, nil
+}
+
+func (e *testEnv) WorkDir() *os.File {
+	return e.wd
+}
+
+func (e *testEnv) Open(p string, flags int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(filepath.Join(e.dir, p), flags, perm)
+}
+
+func (e *testEnv) Mkdir(p string, perm os.FileMode) error {
+	return os.Mkdir(filepath.Join(e.dir, p), perm)
+}
+
+type testProcess struct {
+	done   chan struct{}
+	result runner.Result
+}
+
+func (p *testProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+func (p *testProcess) Result() runner.Result {
+	<-p.done
+	return p.result
+}
+
+func (p *testProcess) Usage() envexec.Usage {
+	return envexec.Usage{}
+}
+
+func closeTestFiles(files []*os.File) {
+	for _, f := range files {
+		if f != nil {
+			f.Close()
+		}
+	}
+}
+
+// newTestWorker creates a worker running the programs without starting the loops
+func newTestWorker(t *testing.T, programs map[string]testProgram) *worker {
+	fs := filestore.NewFileLocalStore(t.TempDir())
+	w := New(Config{
+		FileStore:       fs,
+		EnvironmentPool: &testEnvPool{t: t, programs: programs},
+		CopyOutLimit:    1 << 20,
+		OutputLimit:     1 << 20,
+	}).(*worker)
+	t.Cleanup(w.Shutdown)
+	return w
+}
+
+// testCmd returns the cmd running the program with a generous time limit
+func testCmd(args ...string) Cmd {
+	return Cmd{
+		Args:       args,
+		CPULimit:   10 * time.Second,
+		ClockLimit: 10 * time.Second,
+	}
+}
+
+// testFileStoreIDs returns the ids of the files in the file store
+func testFileStoreIDs(w *worker) []string {
+	var ids []string
+	for id := range w.fs.List() {
+		ids = append(ids, id)
+	}
+	return ids
+}
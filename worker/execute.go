@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// ExecuteStats defines the number of waiting and running requests of Execute
+type ExecuteStats struct {
+	Waiting  int
+	Running  int
+	Rejected bool // Rejected is set when a request was just rejected by the full queue
+}
+
+// executePool limits the concurrency of Execute separately from the worker
+// loops, so streaming sessions cannot disturb the judged requests. Requests
+// over the limit wait in FIFO order and are rejected if the queue is full
+type executePool struct {
+	mu        sync.Mutex
+	size      int // 0 for unlimited
+	queueSize int
+	running   int
+	waiters   *list.List // list of chan struct{}, closed when the slot is granted
+	observer  func(ExecuteStats)
+}
+
+func newExecutePool(size, queueSize int, observer func(ExecuteStats)) *executePool {
+	return &executePool{
+		size:      size,
+		queueSize: queueSize,
+		waiters:   list.New(),
+		observer:  observer,
+	}
+}
+
+// acquire waits for a slot until the context or done is closed,
+// returns ErrQueueFull if there are too many waiting requests
+func (p *executePool) acquire(ctx context.Context, done <-chan struct{}) error {
+	p.mu.Lock()
+	if p.size <= 0 || (p.running < p.size && p.waiters.Len() == 0) {
+		p.running++
+		p.observe(false)
+		p.mu.Unlock()
+		return nil
+	}
+	if p.waiters.Len() >= p.queueSize {
+		p.observe(true)
+		p.mu.Unlock()
+		return ErrQueueFull
+	}
+	ch := make(chan struct{})
+	e := p.waiters.PushBack(ch)
+	p.observe(false)
+	p.mu.Unlock()
+
+	var err error
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-done:
+		err = ErrCancelled
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-ch:
+		// granted concurrently, give it to the next one
+		p.releaseLocked()
+	default:
+		p.waiters.Remove(e)
+		p.observe(false)
+	}
+	return err
+}
+
+// release returns the slot to the first waiting request
+func (p *executePool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.releaseLocked()
+}
+
+func (p *executePool) releaseLocked() {
+	if e := p.waiters.Front(); e != nil {
+		close(p.waiters.Remove(e).(chan struct{}))
+	} else {
+		p.running--
+	}
+	p.observe(false)
+}
+
+func (p *executePool) observe(rejected bool) {
+	if p.observer != nil {
+		p.observer(ExecuteStats{
+			Waiting:  p.waiters.Len(),
+			Running:  p.running,
+			Rejected: rejected,
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireAsync acquires the pool in the background and returns the result channel
+func acquireAsync(ctx context.Context, p *executePool, done <-chan struct{}) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		ch <- p.acquire(ctx, done)
+	}()
+	return ch
+}
+
+// waitExecuteWaiting waits until the pool has n waiting requests
+func waitExecuteWaiting(t *testing.T, p *executePool, n int) {
+	for i := 0; i < 1000; i++ {
+		p.mu.Lock()
+		l := p.waiters.Len()
+		p.mu.Unlock()
+		if l == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d waiting requests", n)
+}
+
+func expectAcquired(t *testing.T, name string, ch <-chan error, acquired bool) {
+	select {
+	case err := <-ch:
+		if !acquired {
+			t.Fatalf("%s: expected waiting, got %v", name, err)
+		}
+		if err != nil {
+			t.Fatalf("%s: expected acquired, got %v", name, err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		if acquired {
+			t.Fatalf("%s: expected acquired, still waiting", name)
+		}
+	}
+}
+
+func TestExecutePoolUnlimited(t *testing.T) {
+	p := newExecutePool(0, 0, nil)
+	for i := 0; i < 10; i++ {
+		if err := p.acquire(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p.running != 10 {
+		t.Errorf("expected 10 running, got %d", p.running)
+	}
+}
+
+func TestExecutePoolQueueAndReject(t *testing.T) {
+	var stats []ExecuteStats
+	p := newExecutePool(1, 2, func(s ExecuteStats) { stats = append(stats, s) })
+	ctx := context.Background()
+
+	if err := p.acquire(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	first := acquireAsync(ctx, p, nil)
+	waitExecuteWaiting(t, p, 1)
+	second := acquireAsync(ctx, p, nil)
+	waitExecuteWaiting(t, p, 2)
+
+	// the queue is full
+	if err := p.acquire(ctx, nil); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected %v, got %v", ErrQueueFull, err)
+	}
+	p.mu.Lock()
+	last := stats[len(stats)-1]
+	p.mu.Unlock()
+	if !last.Rejected || last.Waiting != 2 || last.Running != 1 {
+		t.Errorf("expected rejected stats with 2 waiting and 1 running, got %+v", last)
+	}
+
+	// slots are granted in FIFO order
+	expectAcquired(t, "first", first, false)
+	p.release()
+	expectAcquired(t, "first", first, true)
+	expectAcquired(t, "second", second, false)
+	p.release()
+	expectAcquired(t, "second", second, true)
+
+	p.release()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running != 0 || p.waiters.Len() != 0 {
+		t.Errorf("expected idle pool, got %d running %d waiting", p.running, p.waiters.Len())
+	}
+}
+
+func TestExecutePoolCancel(t *testing.T) {
+	p := newExecutePool(1, 2, nil)
+	if err := p.acquire(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	byCtx := acquireAsync(ctx, p, nil)
+	done := make(chan struct{})
+	byDone := acquireAsync(context.Background(), p, done)
+	waitExecuteWaiting(t, p, 2)
+
+	cancel()
+	if err := <-byCtx; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	close(done)
+	if err := <-byDone; !errors.Is(err, ErrCancelled) {
+		t.Errorf("expected %v, got %v", ErrCancelled, err)
+	}
+	waitExecuteWaiting(t, p, 0)
+
+	// cancelled waiters do not take the released slot
+	p.release()
+	if err := p.acquire(context.Background(), nil); err != nil {
+		t.Errorf("expected acquired after release, got %v", err)
+	}
+}
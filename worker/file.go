@@ -3,6 +3,7 @@ package worker
 import (
 	"bytes"
 	"fmt"
+	"os"
 
 	"github.com/criyle/go-judge/envexec"
 	"github.com/criyle/go-judge/filestore"
@@ -21,6 +22,9 @@ var (
 	_ CmdFile = &MemoryFile{}
 	_ CmdFile = &CachedFile{}
 	_ CmdFile = &Collector{}
+	_ CmdFile = &StageFile{}
+	_ CmdFile = &ArchiveFile{}
+	_ CmdFile = &CopyInFile{}
 )
 
 // LocalFile defines file stores on the local file system
@@ -74,13 +78,81 @@ type Collector struct {
 	Name string       // pseudo name generated into copyOut
 	Max  envexec.Size // max size to be collected
 	Pipe bool
+	Tail envexec.Size // last bytes to be kept after the truncated marker if exceeds max
 }
 
 // EnvFile prepares file for envexec file
 func (f *Collector) EnvFile(fs filestore.FileStore) (envexec.File, error) {
-	return envexec.NewFileCollector(f.Name, f.Max, f.Pipe), nil
+	return &envexec.FileCollector{Name: f.Name, Limit: f.Max, Pipe: f.Pipe, Tail: f.Tail}, nil
 }
 
 func (f *Collector) String() string {
-	return fmt.Sprintf("collector:(name:%s,max:%d,pipe:%v)", f.Name, f.Max, f.Pipe)
+	return fmt.Sprintf("collector:(name:%s,max:%d,pipe:%v,tail:%d)", f.Name, f.Max, f.Pipe, f.Tail)
+}
+
+// StageFile defines file copied out by CopyOutStage in the previous stages
+type StageFile struct {
+	Name string
+}
+
+// EnvFile prepares file for envexec file
+func (f *StageFile) EnvFile(fs filestore.FileStore) (envexec.File, error) {
+	return nil, fmt.Errorf("stage file not exists with name %v", f.Name)
+}
+
+func (f *StageFile) String() string {
+	return fmt.Sprintf("stage:(name:%s)", f.Name)
+}
+
+// ArchiveFile defines archive (tar / tar.gz / zip) from the source file to be
+// extracted into the directory in copyIn
+type ArchiveFile struct {
+	Source     CmdFile
+	Format     envexec.ArchiveFormat
+	Limit      envexec.Size // max total size of the extracted files
+	InodeLimit int          // max number of the extracted files and directories
+}
+
+// EnvFile prepares file for envexec file
+func (f *ArchiveFile) EnvFile(fs filestore.FileStore) (envexec.File, error) {
+	switch f.Source.(type) {
+	case *LocalFile, *MemoryFile, *CachedFile:
+	default:
+		return nil, fmt.Errorf("archive source is not supported %v", f.Source)
+	}
+	src, err := f.Source.EnvFile(fs)
+	if err != nil {
+		return nil, err
+	}
+	return envexec.NewFileArchive(src, f.Format, f.Limit, f.InodeLimit), nil
+}
+
+func (f *ArchiveFile) String() string {
+	return fmt.Sprintf("archive:(%v,format:%v,limit:%d,inodeLimit:%d)", f.Source, f.Format, f.Limit, f.InodeLimit)
+}
+
+// CopyInFile defines copyIn file created with the mode (0777 if 0), its content
+// is verified unchanged after the run if VerifyUnchanged
+type CopyInFile struct {
+	Source          CmdFile
+	Mode            os.FileMode
+	VerifyUnchanged bool
+}
+
+// EnvFile prepares file for envexec file
+func (f *CopyInFile) EnvFile(fs filestore.FileStore) (envexec.File, error) {
+	switch f.Source.(type) {
+	case *LocalFile, *MemoryFile, *CachedFile:
+	default:
+		return nil, fmt.Errorf("copyIn mode is not supported for %v", f.Source)
+	}
+	src, err := f.Source.EnvFile(fs)
+	if err != nil {
+		return nil, err
+	}
+	return envexec.NewFileCopyIn(src, f.Mode, f.VerifyUnchanged), nil
+}
+
+func (f *CopyInFile) String() string {
+	return fmt.Sprintf("copyin:(%v,mode:%v,verifyUnchanged:%v)", f.Source, f.Mode, f.VerifyUnchanged)
 }
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// interactor exit status (testlib convention) for invalid interaction
+const (
+	interactorWrongAnswer       = 1
+	interactorPresentationError = 2
+)
+
+// name of the transcript captured by the proxy
+const transcriptName = "transcript"
+
+// workDoInteractive runs the solution and the interactor connected by pipes
+// and sets the status of the solution by the interactor verdict
+func (w *worker) workDoInteractive(ctx context.Context, it *Interactive) Response {
+	solution, interactor := interactiveCmd(it.Solution), interactiveCmd(it.Interactor)
+	pm := []PipeMap{
+		{
+			In:    PipeIndex{Index: 0, Fd: 1},
+			Out:   PipeIndex{Index: 1, Fd: 0},
+			Proxy: it.TranscriptMax > 0,
+			Name:  transcriptName,
+			Limit: it.TranscriptMax,
+		},
+		{
+			In:    PipeIndex{Index: 1, Fd: 1},
+			Out:   PipeIndex{Index: 0, Fd: 0},
+			Proxy: it.TranscriptMax > 0,
+			Name:  transcriptName,
+			Limit: it.TranscriptMax,
+		},
+	}
+	rt := w.workDoGroup(ctx, []Cmd{solution, interactor}, pm, groupOption{killOnExit: []int{1}})
+	if rt.Error != nil || len(rt.Results) != 2 {
+		return rt
+	}
+
+	rt.Results[0].Status = interactiveStatus(&rt.Results[0], &rt.Results[1])
+	return rt
+}
+
+// interactiveStatus returns the status of the solution by the interactor
+// verdict. The interactor exited non-zero is checked first since the solution
+// may fail (e.g. by SIGPIPE) after the interactor rejected it, the status of
+// the solution is kept if it failed by itself otherwise
+func interactiveStatus(sr, ir *Result) envexec.Status {
+	if ir.Status == envexec.StatusNonzeroExitStatus {
+		if ir.ExitStatus == interactorWrongAnswer || ir.ExitStatus == interactorPresentationError {
+			return envexec.StatusInvalidInteraction
+		}
+		return envexec.StatusJudgementFailed
+	}
+	// solution failed by itself
+	if sr.Status != envexec.StatusAccepted && !sr.Killed {
+		return sr.Status
+	}
+	if ir.Status == envexec.StatusAccepted {
+		return envexec.StatusAccepted
+	}
+	return envexec.StatusJudgementFailed
+}
+
+// interactiveCmd releases stdin and stdout of the cmd for the pipes
+func interactiveCmd(c Cmd) Cmd {
+	files := make([]CmdFile, 2, len(c.Files)+2)
+	if len(c.Files) > 2 {
+		files = append(files, c.Files[2:]...)
+	}
+	c.Files = files
+	return c
+}
@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+func TestInteractiveStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		solution Result
+		killed   bool
+		inter    Result
+		expected envexec.Status
+	}{
+		{"accepted", Result{Status: envexec.StatusAccepted}, false, Result{Status: envexec.StatusAccepted}, envexec.StatusAccepted},
+		{"killed after accepted", Result{Status: envexec.StatusSignalled}, true, Result{Status: envexec.StatusAccepted}, envexec.StatusAccepted},
+		{"wrong answer", Result{Status: envexec.StatusAccepted}, false, Result{Status: envexec.StatusNonzeroExitStatus, ExitStatus: 1}, envexec.StatusInvalidInteraction},
+		{"presentation error", Result{Status: envexec.StatusAccepted}, false, Result{Status: envexec.StatusNonzeroExitStatus, ExitStatus: 2}, envexec.StatusInvalidInteraction},
+		{"wrong answer then sigpipe", Result{Status: envexec.StatusSignalled}, false, Result{Status: envexec.StatusNonzeroExitStatus, ExitStatus: 1}, envexec.StatusInvalidInteraction},
+		{"wrong answer then tle", Result{Status: envexec.StatusTimeLimitExceeded}, false, Result{Status: envexec.StatusNonzeroExitStatus, ExitStatus: 1}, envexec.StatusInvalidInteraction},
+		{"interactor fail", Result{Status: envexec.StatusSignalled}, false, Result{Status: envexec.StatusNonzeroExitStatus, ExitStatus: 3}, envexec.StatusJudgementFailed},
+		{"solution tle", Result{Status: envexec.StatusTimeLimitExceeded}, false, Result{Status: envexec.StatusAccepted}, envexec.StatusTimeLimitExceeded},
+		{"solution tle interactor killed", Result{Status: envexec.StatusTimeLimitExceeded}, false, Result{Status: envexec.StatusSignalled}, envexec.StatusTimeLimitExceeded},
+		{"interactor crashed", Result{Status: envexec.StatusAccepted}, false, Result{Status: envexec.StatusSignalled}, envexec.StatusJudgementFailed},
+	}
+	for _, tc := range tests {
+		sr, ir := tc.solution, tc.inter
+		sr.Killed = tc.killed
+		if got := interactiveStatus(&sr, &ir); got != tc.expected {
+			t.Errorf("%s: status = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
@@ -13,6 +13,8 @@ type Size = envexec.Size
 type CmdCopyOutFile = envexec.CmdCopyOutFile
 type PipeMap = envexec.Pipe
 type PipeIndex = envexec.PipeIndex
+type GroupLimit = envexec.GroupLimit
+type GroupPolicy = envexec.GroupPolicy
 
 // Cmd defines command and limits to start a program using in envexec
 type Cmd struct {
@@ -38,6 +40,77 @@ type Cmd struct {
 	CopyOutCached []CmdCopyOutFile
 	CopyOutMax    uint64
 	CopyOutDir    string
+	// CopyOutDirMax limits the total size of the files dumped by CopyOutDir
+	CopyOutDirMax uint64
+
+	// CopyOutStage defines files to be used by the later stages through StageFile,
+	// they are released when the request finishes
+	CopyOutStage []CmdCopyOutFile
+
+	// Compare compares the collected stdout with the expected output and
+	// sets the status into wrong answer if mismatch
+	Compare *Compare
+
+	// Checker runs the special judge after the cmd accepted and sets the status
+	// by the checker verdict
+	Checker *Checker
+
+	// NoCache disables the result cache for the cmd (e.g. timing matters)
+	NoCache bool
+
+	// IdleLimit terminates the program when neither its cpu time nor its
+	// collected output increases within the limit (e.g. blocked on input),
+	// output written to the pipes without proxy is not tracked
+	IdleLimit time.Duration
+
+	// UsageTimeline records the cpu time and memory usage sampled on every
+	// time limit check into the result
+	UsageTimeline bool
+}
+
+// Stage defines a stage of the multi-stage request
+type Stage struct {
+	Cmd         []Cmd
+	PipeMapping []PipeMap
+	GroupLimit  GroupLimit
+	GroupPolicy GroupPolicy
+	KillOnExit  []int
+	Batch       *Batch
+	Interactive *Interactive
+}
+
+// Batch defines test cases to run one after another inside the same
+// environment. The copy in files are copied once before the first case and
+// verified unchanged after every case if required, the collectors are
+// truncated before every case and other files created by the previous cases
+// are kept
+type Batch struct {
+	Cmd           Cmd // Cmd defines the program and copy in files shared by all cases
+	Cases         []BatchCase
+	StopOnFailure bool // StopOnFailure stops after the first case not accepted
+}
+
+// BatchCase defines the stdin and limits override for a single case
+type BatchCase struct {
+	Stdin       CmdFile // Stdin overrides the first file of the cmd
+	Expected    CmdFile // Expected overrides the expected output of the cmd compare
+	Answer      CmdFile // Answer overrides the answer of the cmd checker
+	CPULimit    time.Duration
+	ClockLimit  time.Duration
+	MemoryLimit Size
+}
+
+// Interactive defines the solution and the interactor connected by pipes both ways.
+// The solution is killed if the interactor exits first, and its status is set by
+// the interactor exit status (1 / 2 for invalid interaction, others for judgement failed)
+// even if the solution failed, the solution status is kept if the interactor exits 0
+type Interactive struct {
+	Solution   Cmd // files[0] and files[1] are connected to the interactor
+	Interactor Cmd // files[0] and files[1] are connected to the solution
+
+	// TranscriptMax enables capturing the data written by each side through proxy,
+	// up to the size, into the file named "transcript" of the writer result
+	TranscriptMax Size
 }
 
 // Request defines single worker request
@@ -45,6 +118,32 @@ type Request struct {
 	RequestID   string
 	Cmd         []Cmd
 	PipeMapping []PipeMap
+
+	// GroupLimit defines the aggregate limits of all Cmd
+	GroupLimit GroupLimit
+
+	// GroupPolicy and KillOnExit define whether the running Cmd are terminated
+	// once other Cmd exit
+	GroupPolicy GroupPolicy
+	KillOnExit  []int
+
+	// Priority defines the scheduling priority, higher priority is executed first
+	Priority int
+
+	// Tenant defines the identity to schedule fairly across tenants, it is
+	// ignored if the tenant requires authentication (see TenantConfig)
+	Tenant string
+
+	// Batch defines test cases to run instead of Cmd, one result for each case
+	Batch *Batch
+
+	// Interactive defines the interactive problem to run instead of Cmd,
+	// results are the solution followed by the interactor
+	Interactive *Interactive
+
+	// Stages defines ordered stages to run instead of Cmd and PipeMapping.
+	// Execution stops after a stage with result not accepted
+	Stages []Stage
 }
 
 // Result defines single command response
@@ -58,6 +157,38 @@ type Result struct {
 	Files      map[string]*os.File
 	FileIDs    map[string]string
 	FileError  []envexec.FileError
+	Diff       string // Diff summarizes the first mismatch of the output compare
+
+	// Score and CheckerMessage are reported by the checker
+	Score          float64
+	CheckerMessage string
+
+	// UsageTimeline is the usage sampled during the run if enabled by the cmd
+	UsageTimeline []UsageSample
+
+	// Attempts are the runs of the cmd if it was run again by the retry policy
+	Attempts []Attempt
+
+	// CopyOutDir is the manifest of the files visited by CopyOutDir
+	CopyOutDir []envexec.CopyOutDirEntry
+
+	// OutputSize is the number of bytes written by the program to each collector
+	OutputSize map[string]envexec.Size
+
+	// GroupLimitExceeded is the aggregate limit of the group that was exceeded
+	GroupLimitExceeded envexec.GroupLimitType
+
+	// Killed indicates the Cmd was terminated because another Cmd in the group exited
+	Killed bool
+
+	stageFileIDs map[string]string
+}
+
+// UsageSample defines the cpu time and memory usage at the elapsed time since the cmd started
+type UsageSample struct {
+	Elapsed time.Duration
+	Time    time.Duration
+	Memory  envexec.Size
 }
 
 // Response defines worker response for single request
@@ -65,6 +196,7 @@ type Response struct {
 	RequestID string
 	Results   []Result
 	Error     error
+	WaitTime  time.Duration // WaitTime is the duration the request waited in the queue
 }
 
 func (r Result) String() string {
@@ -78,6 +210,17 @@ func (r Result) String() string {
 		Files      map[string]string
 		FileIDs    map[string]string
 		FileError  []envexec.FileError
+		Diff       string
+
+		Score          float64
+		CheckerMessage string
+		UsageTimeline  int
+		Attempts       []Attempt
+		CopyOutDir     int
+		OutputSize     map[string]envexec.Size
+
+		GroupLimitExceeded envexec.GroupLimitType
+		Killed             bool
 	}
 	d := Result{
 		Status:     r.Status,
@@ -89,6 +232,17 @@ func (r Result) String() string {
 		Files:      make(map[string]string),
 		FileIDs:    r.FileIDs,
 		FileError:  r.FileError,
+		Diff:       r.Diff,
+
+		Score:          r.Score,
+		CheckerMessage: r.CheckerMessage,
+		UsageTimeline:  len(r.UsageTimeline),
+		Attempts:       r.Attempts,
+		CopyOutDir:     len(r.CopyOutDir),
+		OutputSize:     r.OutputSize,
+
+		GroupLimitExceeded: r.GroupLimitExceeded,
+		Killed:             r.Killed,
 	}
 	for k, v := range r.Files {
 		d.Files[k] = filepath.Base(v.Name())
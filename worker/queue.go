@@ -0,0 +1,335 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// default aging interval 1s
+const defaultPriorityAging = time.Second
+
+// queue holds the waiting requests and serves higher priority first.
+// To avoid starvation, the priority of a waiting request is raised by
+// one for every aging interval it has waited. Requests with the same
+// priority are served by weighted round-robin across tenants.
+type queue struct {
+	mu      sync.Mutex
+	classes map[int]*class
+	tenants map[string]*tenant
+	aging   time.Duration
+
+	tenantConf    map[string]TenantConfig
+	defaultTenant TenantConfig
+	observer      func(TenantStats)
+
+	// slots holds one token for each queued request to limit the capacity
+	slots chan struct{}
+	// changed is closed and renewed when a request could become available
+	changed chan struct{}
+	// closed is closed when the queue stops accepting requests
+	closed chan struct{}
+}
+
+// class holds the waiting requests with the same priority
+type class struct {
+	reqs   map[string][]workRequest
+	order  []string // tenants with waiting requests in round-robin order
+	cur    int      // current tenant in order
+	served int      // number of requests served for the current tenant
+}
+
+// tenant holds the scheduling state of a tenant
+type tenant struct {
+	TenantConfig
+	name    string
+	waiting int
+	running int
+}
+
+func newQueue(size int, aging time.Duration, tenantConf map[string]TenantConfig, defaultTenant TenantConfig, observer func(TenantStats)) *queue {
+	if aging <= 0 {
+		aging = defaultPriorityAging
+	}
+	return &queue{
+		classes:       make(map[int]*class),
+		tenants:       make(map[string]*tenant),
+		aging:         aging,
+		tenantConf:    tenantConf,
+		defaultTenant: defaultTenant,
+		observer:      observer,
+		slots:         make(chan struct{}, size),
+		changed:       make(chan struct{}),
+		closed:        make(chan struct{}),
+	}
+}
+
+// tryPush adds the request into the queue, returns ErrQueueFull if the queue is full
+// or ErrShuttingDown if the queue is closed
+func (q *queue) tryPush(req workRequest) error {
+	select {
+	case <-q.closed:
+		return ErrShuttingDown
+	case q.slots <- struct{}{}:
+	default:
+		return ErrQueueFull
+	}
+	return q.add(req)
+}
+
+// push adds the request into the queue, waits for the capacity until the context is done
+func (q *queue) push(ctx context.Context, req workRequest) error {
+	select {
+	case q.slots <- struct{}{}:
+	case <-q.closed:
+		return ErrShuttingDown
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", ErrQueueFull, ctx.Err())
+	}
+	return q.add(req)
+}
+
+func (q *queue) add(req workRequest) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case <-q.closed:
+		<-q.slots
+		return ErrShuttingDown
+	default:
+	}
+
+	req.enqueued = time.Now()
+	c, ok := q.classes[req.Priority]
+	if !ok {
+		c = &class{reqs: make(map[string][]workRequest)}
+		q.classes[req.Priority] = c
+	}
+	if len(c.reqs[req.tenant]) == 0 {
+		c.order = append(c.order, req.tenant)
+	}
+	c.reqs[req.tenant] = append(c.reqs[req.tenant], req)
+
+	t := q.getTenant(req.tenant)
+	t.waiting++
+	q.observe(t)
+	q.broadcast()
+	return nil
+}
+
+// close stops the queue from accepting new requests
+func (q *queue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case <-q.closed:
+	default:
+		close(q.closed)
+	}
+}
+
+// isClosed returns whether the queue stops accepting new requests
+func (q *queue) isClosed() bool {
+	select {
+	case <-q.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// drain removes and returns all the waiting requests
+func (q *queue) drain() []workRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var rt []workRequest
+	for p, c := range q.classes {
+		for name, reqs := range c.reqs {
+			t := q.tenants[name]
+			t.waiting -= len(reqs)
+			q.observe(t)
+			rt = append(rt, reqs...)
+		}
+		delete(q.classes, p)
+	}
+	for range rt {
+		<-q.slots
+	}
+	q.broadcast()
+	return rt
+}
+
+// waitIdle waits until there is no waiting or running request,
+// returns false if done is closed before
+func (q *queue) waitIdle(done <-chan struct{}) bool {
+	for {
+		q.mu.Lock()
+		idle := len(q.tenants) == 0
+		changed := q.changed
+		q.mu.Unlock()
+		if idle {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-done:
+			return false
+		}
+	}
+}
+
+// pop waits and removes the request with the highest aged priority,
+// returns false if done is closed or quit returns true before any request available
+func (q *queue) pop(done <-chan struct{}, quit func() bool) (workRequest, bool) {
+	for {
+		if quit() {
+			return workRequest{}, false
+		}
+		q.mu.Lock()
+		req, ok := q.next()
+		changed := q.changed
+		q.mu.Unlock()
+		if ok {
+			<-q.slots
+			return req, true
+		}
+
+		select {
+		case <-changed:
+		case <-done:
+			return workRequest{}, false
+		}
+	}
+}
+
+// finish marks the popped request as finished
+func (q *queue) finish(req workRequest) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenants[req.tenant]
+	t.running--
+	q.observe(t)
+	q.broadcast()
+}
+
+func (q *queue) next() (workRequest, bool) {
+	now := time.Now()
+	var (
+		best      *class
+		bestScore time.Duration
+		bestTime  time.Time
+	)
+	for p, c := range q.classes {
+		// the oldest request of a tenant that is able to run
+		var (
+			oldest time.Time
+			found  bool
+		)
+		for name, r := range c.reqs {
+			if !q.available(name) {
+				continue
+			}
+			if !found || r[0].enqueued.Before(oldest) {
+				oldest, found = r[0].enqueued, true
+			}
+		}
+		if !found {
+			continue
+		}
+		score := now.Sub(oldest) + time.Duration(p)*q.aging
+		if best == nil || score > bestScore || (score == bestScore && oldest.Before(bestTime)) {
+			best, bestScore, bestTime = c, score, oldest
+		}
+	}
+	if best == nil {
+		return workRequest{}, false
+	}
+
+	// weighted round-robin across available tenants
+	for !q.available(best.order[best.cur]) || best.served >= q.tenants[best.order[best.cur]].weight() {
+		best.cur = (best.cur + 1) % len(best.order)
+		best.served = 0
+	}
+	name := best.order[best.cur]
+	best.served++
+
+	r := best.reqs[name]
+	req := r[0]
+	r[0] = workRequest{}
+	if len(r) > 1 {
+		best.reqs[name] = r[1:]
+	} else {
+		delete(best.reqs, name)
+		best.order = append(best.order[:best.cur], best.order[best.cur+1:]...)
+		best.served = 0
+		if best.cur >= len(best.order) {
+			best.cur = 0
+		}
+		if len(best.order) == 0 {
+			delete(q.classes, req.Priority)
+		}
+	}
+
+	t := q.tenants[name]
+	t.waiting--
+	t.running++
+	q.observe(t)
+	return req, true
+}
+
+func (q *queue) available(name string) bool {
+	t := q.tenants[name]
+	return t.Parallelism <= 0 || t.running < t.Parallelism
+}
+
+func (q *queue) getTenant(name string) *tenant {
+	if t, ok := q.tenants[name]; ok {
+		return t
+	}
+	conf, ok := q.tenantConf[name]
+	if !ok {
+		conf = q.defaultTenant
+	}
+	t := &tenant{TenantConfig: conf, name: name}
+	q.tenants[name] = t
+	return t
+}
+
+func (q *queue) observe(t *tenant) {
+	if q.observer != nil {
+		q.observer(TenantStats{
+			Tenant:  t.name,
+			Waiting: t.waiting,
+			Running: t.running,
+		})
+	}
+	// remove idle tenant
+	if t.waiting == 0 && t.running == 0 {
+		delete(q.tenants, t.name)
+	}
+}
+
+// wakeup wakes up the waiting pop to check quit
+func (q *queue) wakeup() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.broadcast()
+}
+
+func (q *queue) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func (t *tenant) weight() int {
+	if t.Weight <= 0 {
+		return 1
+	}
+	return t.Weight
+}
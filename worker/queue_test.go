@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func pushTestRequest(t *testing.T, q *queue, id, tenant string, priority int) {
+	err := q.tryPush(workRequest{
+		Request: &Request{RequestID: id, Priority: priority},
+		tenant:  tenant,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func popTestRequests(t *testing.T, q *queue, n int) []string {
+	done := make(chan struct{})
+	close(done)
+	var ids []string
+	for i := 0; i < n; i++ {
+		req, ok := q.pop(done, func() bool { return false })
+		if !ok {
+			break
+		}
+		ids = append(ids, req.RequestID)
+	}
+	return ids
+}
+
+func checkTestRequests(t *testing.T, got []string, expected ...string) {
+	if len(got) != len(expected) {
+		t.Fatalf("popped %v, expected %v", got, expected)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("popped %v, expected %v", got, expected)
+		}
+	}
+}
+
+func TestQueuePriority(t *testing.T) {
+	q := newQueue(10, time.Hour, nil, TenantConfig{}, nil)
+	pushTestRequest(t, q, "low", "", 0)
+	pushTestRequest(t, q, "high", "", 2)
+	pushTestRequest(t, q, "mid", "", 1)
+	pushTestRequest(t, q, "high2", "", 2)
+
+	checkTestRequests(t, popTestRequests(t, q, 5), "high", "high2", "mid", "low")
+}
+
+func TestQueueAging(t *testing.T) {
+	q := newQueue(10, time.Millisecond, nil, TenantConfig{}, nil)
+	pushTestRequest(t, q, "old", "", 0)
+	time.Sleep(20 * time.Millisecond)
+	pushTestRequest(t, q, "new", "", 1)
+
+	// the old request has been aged over the priority of the new one
+	checkTestRequests(t, popTestRequests(t, q, 2), "old", "new")
+}
+
+func TestQueueWeightedRoundRobin(t *testing.T) {
+	q := newQueue(10, time.Hour, map[string]TenantConfig{
+		"a": {Weight: 2},
+	}, TenantConfig{Weight: 1}, nil)
+	for _, id := range []string{"a1", "a2", "a3", "a4"} {
+		pushTestRequest(t, q, id, "a", 0)
+	}
+	for _, id := range []string{"b1", "b2", "b3"} {
+		pushTestRequest(t, q, id, "b", 0)
+	}
+
+	checkTestRequests(t, popTestRequests(t, q, 7), "a1", "a2", "b1", "a3", "a4", "b2", "b3")
+}
+
+func TestQueueTenantParallelism(t *testing.T) {
+	var stats []TenantStats
+	q := newQueue(10, time.Hour, map[string]TenantConfig{
+		"a": {Parallelism: 1},
+	}, TenantConfig{}, func(s TenantStats) {
+		stats = append(stats, s)
+	})
+	pushTestRequest(t, q, "a1", "a", 0)
+	pushTestRequest(t, q, "a2", "a", 0)
+	pushTestRequest(t, q, "b1", "b", 0)
+
+	// a2 waits until a1 finished
+	done := make(chan struct{})
+	close(done)
+	a1, ok := q.pop(done, func() bool { return false })
+	if !ok || a1.RequestID != "a1" {
+		t.Fatalf("expected a1, got %v", a1.Request)
+	}
+	checkTestRequests(t, popTestRequests(t, q, 2), "b1")
+
+	q.finish(a1)
+	checkTestRequests(t, popTestRequests(t, q, 1), "a2")
+
+	if last := stats[len(stats)-1]; last != (TenantStats{Tenant: "a", Running: 1}) {
+		t.Errorf("unexpected tenant stats %v", last)
+	}
+}
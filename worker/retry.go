@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// RetryCombine defines how the result is chosen from the attempts
+type RetryCombine int
+
+// Defines retry combines
+const (
+	RetryCombineMin    RetryCombine = iota // attempt with the least cpu time
+	RetryCombineMedian                     // attempt with the median cpu time, runs all attempts
+)
+
+var retryCombineString = []string{
+	"min",
+	"median",
+}
+
+func (c RetryCombine) String() string {
+	if c >= 0 && int(c) < len(retryCombineString) {
+		return retryCombineString[c]
+	}
+	return "unknown"
+}
+
+// StringToRetryCombine converts the combine name into RetryCombine
+func StringToRetryCombine(s string) (RetryCombine, error) {
+	if s == "" {
+		return RetryCombineMin, nil
+	}
+	for i, n := range retryCombineString {
+		if n == s {
+			return RetryCombine(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid retry combine %q", s)
+}
+
+// RetryPolicy defines when single commands are run again and how the
+// result is chosen from the attempts
+type RetryPolicy struct {
+	// MaxAttempts defines the max number of runs, retry is disabled if <= 1
+	MaxAttempts int
+	// TimeMargin re-runs accepted and time limit exceeded results with the
+	// cpu time within the ratio (e.g. 0.05) of the cpu limit on both sides,
+	// plus a tick of the time limit checker above the limit
+	TimeMargin float64
+	// InternalError re-runs internal error results
+	InternalError bool
+	Combine       RetryCombine
+}
+
+// Attempt defines the status and the usage of a run
+type Attempt struct {
+	Status  envexec.Status
+	Time    time.Duration
+	RunTime time.Duration
+	Memory  envexec.Size
+}
+
+// retryable returns true if the cmd can be run again with the same inputs
+func retryable(c Cmd) bool {
+	if c.TTY {
+		return false
+	}
+	for _, f := range c.Files {
+		switch f.(type) {
+		case nil, *LocalFile, *MemoryFile, *CachedFile, *Collector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// needRetry returns true if the result should be run again by the policy,
+// tick is the interval of the time limit checker
+func (p *RetryPolicy) needRetry(res Result, c Cmd, tick time.Duration) bool {
+	switch res.Status {
+	case envexec.StatusInternalError:
+		return p.InternalError
+
+	case envexec.StatusAccepted, envexec.StatusTimeLimitExceeded:
+		if p.TimeMargin <= 0 || c.CPULimit <= 0 || res.Error == idleLimitExceeded {
+			return false
+		}
+		return p.borderline(res.Time, c.CPULimit, tick)
+	}
+	return false
+}
+
+// borderline returns true if the cpu time is within the margin on both sides
+// of the cpu limit. The cpu time exceeds the limit up to a tick of the time
+// limit checker before it is killed, so the margin above the limit includes
+// the tick
+func (p *RetryPolicy) borderline(t, limit, tick time.Duration) bool {
+	margin := time.Duration(p.TimeMargin * float64(limit))
+	return t >= limit-margin && t <= limit+tick+margin
+}
+
+// workDoRetry runs the single cmd until the result is not borderline and
+// chooses the result from the attempts by the policy
+func (w *worker) workDoRetry(ctx context.Context, rc Cmd) (Result, error) {
+	p := &w.retry
+	if p.MaxAttempts <= 1 || !retryable(rc) {
+		return w.runSingle(ctx, rc)
+	}
+
+	tick := w.timeLimitTickInterval
+	if tick == 0 {
+		tick = defaultTickInterval
+	}
+	var results []Result
+	for {
+		res, err := w.runSingle(ctx, rc)
+		if err != nil {
+			for _, r := range results {
+				w.releaseResult(r)
+			}
+			return res, err
+		}
+		results = append(results, res)
+		if len(results) >= p.MaxAttempts || ctx.Err() != nil {
+			break
+		}
+		// median runs all attempts once the first one is borderline
+		retry := p.needRetry(res, rc, tick)
+		if p.Combine == RetryCombineMedian && len(results) > 1 {
+			retry = true
+		}
+		if !retry {
+			break
+		}
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+
+	chosen := p.choose(results)
+	res := results[chosen]
+	for i, r := range results {
+		if i != chosen {
+			w.releaseResult(r)
+		}
+		res.Attempts = append(res.Attempts, Attempt{
+			Status:  r.Status,
+			Time:    r.Time,
+			RunTime: r.RunTime,
+			Memory:  r.Memory,
+		})
+	}
+	return res, nil
+}
+
+// choose returns the index of the chosen result, internal errors are only
+// chosen if all attempts failed
+func (p *RetryPolicy) choose(results []Result) int {
+	idx := make([]int, 0, len(results))
+	for i, r := range results {
+		if r.Status != envexec.StatusInternalError {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return len(results) - 1
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return results[idx[i]].Time < results[idx[j]].Time
+	})
+	if p.Combine == RetryCombineMedian {
+		return idx[(len(idx)-1)/2]
+	}
+	return idx[0]
+}
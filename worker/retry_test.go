@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+func TestRetryPolicyNeedRetry(t *testing.T) {
+	const (
+		limit = time.Second
+		tick  = 100 * time.Millisecond
+	)
+	p := RetryPolicy{MaxAttempts: 3, TimeMargin: 0.05}
+	tests := []struct {
+		name   string
+		status envexec.Status
+		time   time.Duration
+		err    string
+		retry  bool
+	}{
+		{"accepted fast", envexec.StatusAccepted, 900 * time.Millisecond, "", false},
+		{"accepted borderline", envexec.StatusAccepted, 960 * time.Millisecond, "", true},
+		{"accepted at limit", envexec.StatusAccepted, limit, "", true},
+		{"tle overshoot", envexec.StatusTimeLimitExceeded, limit + tick, "", true},
+		{"tle overshoot margin", envexec.StatusTimeLimitExceeded, limit + tick + 40*time.Millisecond, "", true},
+		{"tle far", envexec.StatusTimeLimitExceeded, 2 * limit, "", false},
+		{"tle idle", envexec.StatusTimeLimitExceeded, limit, idleLimitExceeded, false},
+		{"wrong answer", envexec.StatusWrongAnswer, limit, "", false},
+		{"internal error", envexec.StatusInternalError, 0, "", false},
+	}
+	for _, tc := range tests {
+		res := Result{Status: tc.status, Time: tc.time, Error: tc.err}
+		if got := p.needRetry(res, Cmd{CPULimit: limit}, tick); got != tc.retry {
+			t.Errorf("%s: needRetry = %v, expected %v", tc.name, got, tc.retry)
+		}
+	}
+
+	p.InternalError = true
+	if !p.needRetry(Result{Status: envexec.StatusInternalError}, Cmd{CPULimit: limit}, tick) {
+		t.Errorf("internal error should be retried")
+	}
+	if p.needRetry(Result{Status: envexec.StatusAccepted, Time: limit}, Cmd{}, tick) {
+		t.Errorf("cmd without cpu limit should not be retried")
+	}
+}
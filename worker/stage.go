@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// workDoStages runs stages in order and stops after a stage not accepted.
+// Files copied out for the later stages are removed from the file store after finish
+func (w *worker) workDoStages(ctx context.Context, stages []Stage) (rt Response) {
+	var stageFileIDs []string
+	stageFiles := make(map[string]string)
+	defer func() {
+		for _, id := range stageFileIDs {
+			w.fs.Remove(id)
+		}
+	}()
+
+	for _, s := range stages {
+		var srt Response
+		if s.Batch != nil {
+			b := *s.Batch
+			b.Cmd = resolveStageFiles([]Cmd{b.Cmd}, stageFiles)[0]
+			b.Cases = make([]BatchCase, 0, len(s.Batch.Cases))
+			for _, bc := range s.Batch.Cases {
+				bc.Stdin = resolveStageFile(bc.Stdin, stageFiles)
+				bc.Expected = resolveStageFile(bc.Expected, stageFiles)
+				bc.Answer = resolveStageFile(bc.Answer, stageFiles)
+				b.Cases = append(b.Cases, bc)
+			}
+			srt = w.workDoBatch(ctx, &b)
+		} else if s.Interactive != nil {
+			it := *s.Interactive
+			cmd := resolveStageFiles([]Cmd{it.Solution, it.Interactor}, stageFiles)
+			it.Solution, it.Interactor = cmd[0], cmd[1]
+			srt = w.workDoInteractive(ctx, &it)
+		} else {
+			cmd := resolveStageFiles(s.Cmd, stageFiles)
+			srt = w.workDoStage(ctx, cmd, s.PipeMapping, groupOption{
+				killOnExit: s.KillOnExit,
+				policy:     s.GroupPolicy,
+				limit:      s.GroupLimit,
+			})
+		}
+		rt.Results = append(rt.Results, srt.Results...)
+		if srt.Error != nil {
+			rt.Error = srt.Error
+			return
+		}
+
+		accepted := true
+		for _, r := range srt.Results {
+			for name, id := range r.stageFileIDs {
+				stageFiles[name] = id
+				stageFileIDs = append(stageFileIDs, id)
+			}
+			if r.Status != envexec.StatusAccepted {
+				accepted = false
+			}
+		}
+		if !accepted {
+			return
+		}
+	}
+	return
+}
+
+func (w *worker) workDoStage(ctx context.Context, cmd []Cmd, pm []PipeMap, opt groupOption) Response {
+	if len(cmd) == 1 && opt.limit == (GroupLimit{}) {
+		return w.workDoSingle(ctx, cmd[0])
+	}
+	return w.workDoGroup(ctx, cmd, pm, opt)
+}
+
+// resolveStageFiles replaces stage files with the cached files from the previous stages
+func resolveStageFiles(cmd []Cmd, stageFiles map[string]string) []Cmd {
+	rt := make([]Cmd, 0, len(cmd))
+	for _, c := range cmd {
+		files := make([]CmdFile, 0, len(c.Files))
+		for _, f := range c.Files {
+			files = append(files, resolveStageFile(f, stageFiles))
+		}
+		c.Files = files
+
+		if c.CopyIn != nil {
+			copyIn := make(map[string]CmdFile, len(c.CopyIn))
+			for n, f := range c.CopyIn {
+				copyIn[n] = resolveStageFile(f, stageFiles)
+			}
+			c.CopyIn = copyIn
+		}
+		if c.Compare != nil {
+			cmp := *c.Compare
+			cmp.Expected = resolveStageFile(cmp.Expected, stageFiles)
+			c.Compare = &cmp
+		}
+		if c.Checker != nil {
+			ck := *c.Checker
+			ck.Cmd = resolveStageFiles([]Cmd{ck.Cmd}, stageFiles)[0]
+			ck.Input = resolveStageFile(ck.Input, stageFiles)
+			ck.Answer = resolveStageFile(ck.Answer, stageFiles)
+			c.Checker = &ck
+		}
+		rt = append(rt, c)
+	}
+	return rt
+}
+
+func resolveStageFile(f CmdFile, stageFiles map[string]string) CmdFile {
+	switch cf := f.(type) {
+	case *ArchiveFile:
+		a := *cf
+		a.Source = resolveStageFile(a.Source, stageFiles)
+		return &a
+	case *CopyInFile:
+		c := *cf
+		c.Source = resolveStageFile(c.Source, stageFiles)
+		return &c
+	}
+	sf, ok := f.(*StageFile)
+	if !ok {
+		return f
+	}
+	id, ok := stageFiles[sf.Name]
+	if !ok {
+		return f
+	}
+	return &CachedFile{FileID: id}
+}
@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+var stageTestPrograms = map[string]testProgram{
+	// write creates the file args[1] with content args[2]
+	"write": func(ctx context.Context, dir string, args []string, files []*os.File) int {
+		if err := os.WriteFile(filepath.Join(dir, args[1]), []byte(args[2]), 0644); err != nil {
+			return 1
+		}
+		return 0
+	},
+	// cat writes the file args[1] to stdout
+	"cat": func(ctx context.Context, dir string, args []string, files []*os.File) int {
+		f, err := os.Open(filepath.Join(dir, args[1]))
+		if err != nil {
+			return 1
+		}
+		defer f.Close()
+		if _, err := io.Copy(files[1], f); err != nil {
+			return 1
+		}
+		return 0
+	},
+	"fail": func(ctx context.Context, dir string, args []string, files []*os.File) int {
+		return 1
+	},
+}
+
+func stageTestWrite(name, content string) Stage {
+	c := testCmd("write", name, content)
+	c.CopyOutStage = []CmdCopyOutFile{{Name: name}}
+	return Stage{Cmd: []Cmd{c}}
+}
+
+func stageTestCat(stageFile string) Stage {
+	c := testCmd("cat", "in")
+	c.Files = []CmdFile{nil, &Collector{Name: "stdout", Max: 1024}}
+	c.CopyIn = map[string]CmdFile{"in": &StageFile{Name: stageFile}}
+	return Stage{Cmd: []Cmd{c}}
+}
+
+func TestStagesStageFile(t *testing.T) {
+	w := newTestWorker(t, stageTestPrograms)
+	rt := w.workDoStages(context.Background(), []Stage{
+		stageTestWrite("exe", "compiled"),
+		stageTestCat("exe"),
+	})
+	if rt.Error != nil {
+		t.Fatal(rt.Error)
+	}
+	if len(rt.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(rt.Results))
+	}
+	for i, r := range rt.Results {
+		if r.Status != envexec.StatusAccepted {
+			t.Fatalf("stage %d: status %v: %s", i, r.Status, r.Error)
+		}
+	}
+	if _, ok := rt.Results[0].FileIDs["exe"]; ok {
+		t.Error("stage file is returned as a cached file")
+	}
+	f := rt.Results[1].Files["stdout"]
+	if f == nil {
+		t.Fatal("stdout not collected")
+	}
+	defer f.Close()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "compiled" {
+		t.Errorf("expected stage file content %q, got %q", "compiled", b)
+	}
+	// the collected file is created in the file store as well
+	os.Remove(f.Name())
+	if ids := testFileStoreIDs(w); len(ids) != 0 {
+		t.Errorf("expected stage files released, got %v", ids)
+	}
+}
+
+func TestStagesStageFileRelease(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []Stage
+		status []envexec.Status
+		err    string
+	}{
+		{
+			name: "not accepted",
+			stages: []Stage{
+				stageTestWrite("exe", "compiled"),
+				{Cmd: []Cmd{testCmd("fail")}},
+				stageTestCat("exe"),
+			},
+			status: []envexec.Status{envexec.StatusAccepted, envexec.StatusNonzeroExitStatus},
+		},
+		{
+			name: "missing reference",
+			stages: []Stage{
+				stageTestWrite("exe", "compiled"),
+				stageTestCat("other"),
+			},
+			status: []envexec.Status{envexec.StatusAccepted},
+			err:    "stage file not exists with name other",
+		},
+		{
+			name: "later reference",
+			stages: []Stage{
+				stageTestCat("exe"),
+				stageTestWrite("exe", "compiled"),
+			},
+			err: "stage file not exists with name exe",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newTestWorker(t, stageTestPrograms)
+			rt := w.workDoStages(context.Background(), tc.stages)
+			if tc.err == "" && rt.Error != nil {
+				t.Fatal(rt.Error)
+			}
+			if tc.err != "" && (rt.Error == nil || !strings.Contains(rt.Error.Error(), tc.err)) {
+				t.Fatalf("expected error %q, got %v", tc.err, rt.Error)
+			}
+			if len(rt.Results) != len(tc.status) {
+				t.Fatalf("expected %d results, got %d", len(tc.status), len(rt.Results))
+			}
+			for i, r := range rt.Results {
+				if r.Status != tc.status[i] {
+					t.Errorf("stage %d: expected status %v, got %v", i, tc.status[i], r.Status)
+				}
+			}
+			if ids := testFileStoreIDs(w); len(ids) != 0 {
+				t.Errorf("expected stage files released, got %v", ids)
+			}
+		})
+	}
+}
+
+func TestResolveStageFiles(t *testing.T) {
+	stageFiles := map[string]string{"exe": "id"}
+	c := Cmd{
+		Files:  []CmdFile{&StageFile{Name: "exe"}, &StageFile{Name: "other"}},
+		CopyIn: map[string]CmdFile{"a": &ArchiveFile{Source: &StageFile{Name: "exe"}}},
+		Compare: &Compare{
+			Expected: &StageFile{Name: "exe"},
+		},
+		Checker: &Checker{
+			Cmd:    Cmd{CopyIn: map[string]CmdFile{"b": &CopyInFile{Source: &StageFile{Name: "exe"}}}},
+			Answer: &StageFile{Name: "exe"},
+		},
+	}
+	r := resolveStageFiles([]Cmd{c}, stageFiles)[0]
+
+	isCached := func(name string, f CmdFile) {
+		if cf, ok := f.(*CachedFile); !ok || cf.FileID != "id" {
+			t.Errorf("%s: expected cached file id, got %v", name, f)
+		}
+	}
+	isCached("files[0]", r.Files[0])
+	isCached("archive", r.CopyIn["a"].(*ArchiveFile).Source)
+	isCached("compare", r.Compare.Expected)
+	isCached("checker copy in", r.Checker.Cmd.CopyIn["b"].(*CopyInFile).Source)
+	isCached("checker answer", r.Checker.Answer)
+
+	// unresolved stage file reports the error when prepared
+	if _, err := r.Files[1].EnvFile(nil); err == nil {
+		t.Error("expected error for the missing stage file")
+	}
+	// the original cmd is not modified
+	if _, ok := c.Files[0].(*StageFile); !ok {
+		t.Error("resolve modified the original cmd")
+	}
+	if _, ok := c.Compare.Expected.(*StageFile); !ok {
+		t.Error("resolve modified the original compare")
+	}
+}
@@ -0,0 +1,62 @@
+package worker
+
+import "context"
+
+// TenantConfig defines the scheduling parameters for a tenant
+type TenantConfig struct {
+	Weight      int // Weight defines the share in weighted round-robin (default 1)
+	Parallelism int // Parallelism limits the number of running requests (0 for unlimited)
+	// Authenticated means the tenant is only identified by the context
+	// (e.g. auth token), requests claiming it by the Tenant field are
+	// scheduled as the default tenant
+	Authenticated bool
+}
+
+// TenantStats defines the number of waiting and running requests of a tenant
+type TenantStats struct {
+	Tenant  string
+	Waiting int
+	Running int
+}
+
+type tenantKey struct{}
+
+type adminKey struct{}
+
+// WithTenant returns a context carries the tenant identity, which overrides
+// the tenant specified in the request (e.g. tenant derived from auth token)
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant identity carried by the context
+func TenantFromContext(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(tenantKey{}).(string)
+	return t, ok
+}
+
+// WithAdmin returns a context marked as authenticated by the admin token
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminKey{}, true)
+}
+
+// IsAdmin reports whether the context is marked by WithAdmin
+func IsAdmin(ctx context.Context) bool {
+	a, _ := ctx.Value(adminKey{}).(bool)
+	return a
+}
+
+// requestTenant returns the tenant identity carried by the context, or the
+// tenant specified by the request if it does not require authentication
+func (w *worker) requestTenant(ctx context.Context, req *Request) string {
+	if t, ok := TenantFromContext(ctx); ok {
+		return t
+	}
+	if w.queue.tenantConf[req.Tenant].Authenticated {
+		return ""
+	}
+	return req.Tenant
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestTenant(t *testing.T) {
+	w := New(Config{
+		QueueSize: 1,
+		Tenants: map[string]TenantConfig{
+			"auth":   {Authenticated: true},
+			"public": {},
+		},
+	}).(*worker)
+	defer w.Shutdown()
+
+	tests := []struct {
+		name     string
+		ctx      string
+		req      string
+		expected string
+	}{
+		{"no tenant", "", "", ""},
+		{"public claim", "", "public", "public"},
+		{"unknown claim", "", "other", "other"},
+		{"authenticated claim", "", "auth", ""},
+		{"context", "auth", "", "auth"},
+		{"context overrides claim", "public", "auth", "public"},
+	}
+	for _, tc := range tests {
+		ctx := WithTenant(context.Background(), tc.ctx)
+		if got := w.requestTenant(ctx, &Request{Tenant: tc.req}); got != tc.expected {
+			t.Errorf("%s: tenant = %q, expected %q", tc.name, got, tc.expected)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/criyle/go-judge/envexec"
@@ -10,10 +11,28 @@ import (
 // default tick interval 100 ms
 const defaultTickInterval = 100 * time.Millisecond
 
+// error reason of the result killed by the idle limit
+const idleLimitExceeded = "idle limit exceeded"
+
+// max number of usage samples, the samples are halved once reached
+const maxUsageSamples = 512
+
 type waiter struct {
 	tickInterval  time.Duration
 	timeLimit     time.Duration
 	realTimeLimit time.Duration
+
+	// idleLimit kills the process when neither the cpu time nor the collected
+	// output increases within the limit, idle is set once it happens
+	idleLimit time.Duration
+	idle      bool
+
+	// record enables the usage samples taken on every stride ticks, mu protects
+	// idle and the samples since the waiter may not exit before the result is collected
+	record  bool
+	mu      sync.Mutex
+	stride  int
+	samples []UsageSample
 }
 
 func (w *waiter) Wait(ctx context.Context, u envexec.Process) bool {
@@ -31,7 +50,11 @@ func (w *waiter) Wait(ctx context.Context, u envexec.Process) bool {
 	ticker := time.NewTicker(tickInterval)
 	defer ticker.Stop()
 
-	for {
+	var last envexec.Usage
+	lastActive := start
+
+	w.stride = 1
+	for tick := 1; ; tick++ {
 		select {
 		case <-ctx.Done():
 			return false
@@ -44,9 +67,71 @@ func (w *waiter) Wait(ctx context.Context, u envexec.Process) bool {
 				return true
 			}
 			u := u.Usage()
+			if w.record && tick%w.stride == 0 {
+				w.sample(time.Since(start), u)
+			}
 			if u.Time > w.timeLimit {
 				return true
 			}
+			if w.idleLimit > 0 {
+				if u.Time != last.Time || u.Output != last.Output {
+					last, lastActive = u, time.Now()
+				} else if time.Since(lastActive) > w.idleLimit {
+					w.mu.Lock()
+					w.idle = true
+					w.mu.Unlock()
+					return true
+				}
+			}
+		}
+	}
+}
+
+// sample records the usage and keeps every other sample once the samples are full
+func (w *waiter) sample(elapsed time.Duration, u envexec.Usage) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.samples) >= maxUsageSamples {
+		n := 0
+		for i := 1; i < len(w.samples); i += 2 {
+			w.samples[n] = w.samples[i]
+			n++
 		}
+		w.samples = w.samples[:n]
+		w.stride *= 2
+	}
+	w.samples = append(w.samples, UsageSample{
+		Elapsed: elapsed,
+		Time:    u.Time,
+		Memory:  u.Memory,
+	})
+}
+
+// timeline returns the usage samples ended with the final usage of the result
+func (w *waiter) timeline(result envexec.Result) []UsageSample {
+	if w == nil {
+		return nil
+	}
+	if !w.record {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append(w.samples[:len(w.samples):len(w.samples)], UsageSample{
+		Elapsed: result.RunTime,
+		Time:    result.Time,
+		Memory:  result.Memory,
+	})
+}
+
+// idled returns whether the process was killed by the idle limit
+func (w *waiter) idled() bool {
+	if w == nil {
+		return false
 	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.idle
 }
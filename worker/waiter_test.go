@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+func TestWaiterSampleHalving(t *testing.T) {
+	w := &waiter{record: true, stride: 1}
+	for i := 0; i < maxUsageSamples; i++ {
+		w.sample(time.Duration(i), envexec.Usage{Time: time.Duration(i)})
+	}
+	if len(w.samples) != maxUsageSamples || w.stride != 1 {
+		t.Fatalf("expected %d samples with stride 1, got %d with stride %d", maxUsageSamples, len(w.samples), w.stride)
+	}
+
+	// the samples are halved once full, keeping every other sample
+	w.sample(maxUsageSamples, envexec.Usage{Time: maxUsageSamples})
+	if len(w.samples) != maxUsageSamples/2+1 || w.stride != 2 {
+		t.Fatalf("expected %d samples with stride 2, got %d with stride %d", maxUsageSamples/2+1, len(w.samples), w.stride)
+	}
+	for i, s := range w.samples[:maxUsageSamples/2] {
+		if s.Elapsed != time.Duration(2*i+1) {
+			t.Fatalf("sample %d: expected elapsed %d, got %d", i, 2*i+1, s.Elapsed)
+		}
+	}
+	if last := w.samples[len(w.samples)-1]; last.Elapsed != maxUsageSamples {
+		t.Errorf("expected the new sample appended, got %v", last.Elapsed)
+	}
+}
+
+func TestWaiterTimeline(t *testing.T) {
+	var w *waiter
+	if tl := w.timeline(envexec.Result{}); tl != nil {
+		t.Errorf("expected nil timeline for nil waiter, got %v", tl)
+	}
+	w = &waiter{}
+	if tl := w.timeline(envexec.Result{}); tl != nil {
+		t.Errorf("expected nil timeline without record, got %v", tl)
+	}
+
+	w = &waiter{record: true, stride: 1}
+	w.sample(time.Millisecond, envexec.Usage{Time: time.Millisecond, Memory: 1})
+	result := envexec.Result{RunTime: 2 * time.Millisecond, Time: 2 * time.Millisecond, Memory: 2}
+	tl := w.timeline(result)
+	expected := []UsageSample{
+		{Elapsed: time.Millisecond, Time: time.Millisecond, Memory: 1},
+		{Elapsed: 2 * time.Millisecond, Time: 2 * time.Millisecond, Memory: 2},
+	}
+	if len(tl) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, tl)
+	}
+	for i := range tl {
+		if tl[i] != expected[i] {
+			t.Errorf("sample %d: expected %v, got %v", i, expected[i], tl[i])
+		}
+	}
+	// the final usage is not appended to the samples of the waiter
+	if len(w.samples) != 1 {
+		t.Errorf("expected the waiter samples unchanged, got %d", len(w.samples))
+	}
+}
+
+// usageProcess reports the cpu time increased on every Usage call
+type usageProcess struct {
+	done  chan struct{}
+	calls int64
+}
+
+func (p *usageProcess) Done() <-chan struct{} { return p.done }
+func (p *usageProcess) Result() envexec.RunnerResult {
+	<-p.done
+	return envexec.RunnerResult{}
+}
+func (p *usageProcess) Usage() envexec.Usage {
+	n := atomic.AddInt64(&p.calls, 1)
+	return envexec.Usage{Time: time.Duration(n) * time.Millisecond}
+}
+
+func TestWaiterRecord(t *testing.T) {
+	w := &waiter{
+		tickInterval:  time.Millisecond,
+		timeLimit:     5 * time.Millisecond,
+		realTimeLimit: time.Minute,
+		record:        true,
+	}
+	p := &usageProcess{done: make(chan struct{})}
+	if !w.Wait(context.Background(), p) {
+		t.Fatal("expected time limit exceeded")
+	}
+	// a sample is taken on every tick before the limit is exceeded
+	if len(w.samples) != 6 {
+		t.Fatalf("expected 6 samples, got %d", len(w.samples))
+	}
+	for i, s := range w.samples {
+		if s.Time != time.Duration(i+1)*time.Millisecond {
+			t.Errorf("sample %d: expected time %v, got %v", i, time.Duration(i+1)*time.Millisecond, s.Time)
+		}
+	}
+
+	w = &waiter{tickInterval: time.Millisecond, timeLimit: 5 * time.Millisecond, realTimeLimit: time.Minute}
+	if !w.Wait(context.Background(), &usageProcess{done: make(chan struct{})}) {
+		t.Fatal("expected time limit exceeded")
+	}
+	if len(w.samples) != 0 {
+		t.Errorf("expected no samples without record, got %d", len(w.samples))
+	}
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -12,7 +13,16 @@ import (
 	"github.com/criyle/go-judge/filestore"
 )
 
-const maxWaiting = 512
+const defaultQueueSize = 512
+
+var (
+	// ErrQueueFull is returned when the request cannot be admitted into the worker queue
+	ErrQueueFull = errors.New("worker queue is full")
+	// ErrShuttingDown is returned when the request is submitted after the worker starts to drain
+	ErrShuttingDown = errors.New("worker is shutting down")
+	// ErrCancelled is returned when the request is cancelled by the worker shutdown
+	ErrCancelled = errors.New("request cancelled by worker shutdown")
+)
 
 // EnvironmentPool defines pools for environment to be used to execute commands
 type EnvironmentPool interface {
@@ -30,8 +40,57 @@ type Config struct {
 	ExtraMemoryLimit      envexec.Size
 	OutputLimit           envexec.Size
 	CopyOutLimit          envexec.Size
+	CopyOutDirLimit       envexec.Size
 	OpenFileLimit         uint64
+	PriorityAging         time.Duration
 	ExecObserver          func(Response)
+
+	// AuditObserver is called with each finished request and its response,
+	// the tenant of the request is resolved from the context if carried
+	AuditObserver func(*Request, Response)
+
+	// ArchiveLimit and ArchiveInodeLimit define the default limits of the
+	// size and the number of files extracted from copyIn archives
+	ArchiveLimit      envexec.Size
+	ArchiveInodeLimit int
+
+	// ResultCacheSize enables the result cache for single commands with the
+	// max total size of the cached copy out files and ResultCacheAge defines
+	// the max age of the cached results (0 for unlimited)
+	ResultCacheSize envexec.Size
+	ResultCacheAge  time.Duration
+	// CacheObserver is called for each lookup of the result cache
+	CacheObserver func(hit bool)
+
+	// Retry defines the policy to run single commands again
+	Retry RetryPolicy
+	// ParallelismObserver is called when the target or the current number of
+	// worker loops changes, it must not block
+	ParallelismObserver func(target, current int)
+
+	// ExecuteParallelism limits the number of running requests of Execute
+	// (streaming), separately from Parallelism. 0 for unlimited
+	ExecuteParallelism int
+	// ExecuteQueueSize defines the maximum number of Execute requests waiting
+	// for the slots, requests beyond are rejected with ErrQueueFull
+	ExecuteQueueSize int
+	// ExecuteObserver is called when the number of waiting or running Execute
+	// requests changes, it must not block
+	ExecuteObserver func(ExecuteStats)
+
+	// QueueSize defines the maximum number of waiting requests
+	QueueSize int
+	// QueueBlocking waits for the queue capacity until the request context is
+	// done instead of rejecting the request immediately when the queue is full
+	QueueBlocking bool
+
+	// Tenants defines the scheduling parameters for each tenant and
+	// DefaultTenant applies to tenants not specified
+	Tenants       map[string]TenantConfig
+	DefaultTenant TenantConfig
+	// TenantObserver is called when the number of waiting or running requests
+	// of a tenant changes, it must not block
+	TenantObserver func(TenantStats)
 }
 
 // Worker defines interface for executor
@@ -39,7 +98,14 @@ type Worker interface {
 	Start()
 	Submit(context.Context, *Request) (<-chan Response, <-chan struct{})
 	Execute(context.Context, *Request) <-chan Response
+	Drain(ctx context.Context, waitQueued bool)
 	Shutdown()
+
+	// SetParallelism resizes the number of worker loops, extra loops exit after
+	// their running requests finish
+	SetParallelism(int)
+	// Parallelism returns the target and the current number of worker loops
+	Parallelism() (target, current int)
 }
 
 // worker defines executor worker
@@ -53,15 +119,40 @@ type worker struct {
 	extraMemoryLimit      envexec.Size
 	outputLimit           envexec.Size
 	copyOutLimit          envexec.Size
+	copyOutDirLimit       envexec.Size
 	openFileLimit         uint64
+	archiveLimit          envexec.Size
+	archiveInodeLimit     int
+	queueBlocking         bool
 
-	execObserver func(Response)
+	execObserver  func(Response)
+	auditObserver func(*Request, Response)
+	cacheObserver func(bool)
+
+	parallelismObserver func(int, int)
+
+	cache   *resultCache
+	execute *executePool
+	retry   RetryPolicy
 
 	startOnce sync.Once
 	stopOnce  sync.Once
+	killOnce  sync.Once
 	wg        sync.WaitGroup
-	workCh    chan workRequest
+	queue     *queue
 	done      chan struct{}
+	// kill is closed to cancel the running requests after the drain timeout
+	kill chan struct{}
+
+	// execMu protects execWg from adding after the worker starts to drain
+	execMu sync.Mutex
+	execWg sync.WaitGroup
+
+	// loopMu protects the parallelism and the number of running loops
+	loopMu  sync.Mutex
+	loops   int
+	started bool
+	stopped bool
 }
 
 type workRequest struct {
@@ -69,10 +160,23 @@ type workRequest struct {
 	context.Context
 	started  chan<- struct{}
 	resultCh chan<- Response
+	tenant   string
+	enqueued time.Time
+	submit   time.Time
 }
 
 // New creates new worker
 func New(conf Config) Worker {
+	var cache *resultCache
+	if conf.ResultCacheSize > 0 {
+		cache = newResultCache(conf.ResultCacheSize, conf.ResultCacheAge)
+	}
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	// the queue is created here so that Execute, Drain and Shutdown are
+	// safe to call before Start
 	return &worker{
 		fs:                    conf.FileStore,
 		envPool:               conf.EnvironmentPool,
@@ -82,116 +186,327 @@ func New(conf Config) Worker {
 		extraMemoryLimit:      conf.ExtraMemoryLimit,
 		outputLimit:           conf.OutputLimit,
 		copyOutLimit:          conf.CopyOutLimit,
+		copyOutDirLimit:       conf.CopyOutDirLimit,
 		openFileLimit:         conf.OpenFileLimit,
+		archiveLimit:          conf.ArchiveLimit,
+		archiveInodeLimit:     conf.ArchiveInodeLimit,
+		queueBlocking:         conf.QueueBlocking,
 		execObserver:          conf.ExecObserver,
+		auditObserver:         conf.AuditObserver,
+		cacheObserver:         conf.CacheObserver,
+		parallelismObserver:   conf.ParallelismObserver,
+		cache:                 cache,
+		retry:                 conf.Retry,
+		execute:               newExecutePool(conf.ExecuteParallelism, conf.ExecuteQueueSize, conf.ExecuteObserver),
+		queue:                 newQueue(queueSize, conf.PriorityAging, conf.Tenants, conf.DefaultTenant, conf.TenantObserver),
+		done:                  make(chan struct{}),
+		kill:                  make(chan struct{}),
 	}
 }
 
 // Start starts worker loops with given parallelism
 func (w *worker) Start() {
 	w.startOnce.Do(func() {
-		w.workCh = make(chan workRequest, maxWaiting)
-		w.done = make(chan struct{})
-		w.wg.Add(w.parallelism)
-		for i := 0; i < w.parallelism; i++ {
-			go w.loop()
-		}
+		w.loopMu.Lock()
+		w.started = true
+		w.loopMu.Unlock()
+		w.SetParallelism(w.parallelism)
 	})
 }
 
-// Submit submits a single request
+// SetParallelism resizes the number of worker loops. New loops start immediately
+// and extra loops exit after their running requests finish
+func (w *worker) SetParallelism(n int) {
+	if n < 0 {
+		n = 0
+	}
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+
+	if w.stopped {
+		return
+	}
+	w.parallelism = n
+	// loops are started by Start
+	if !w.started {
+		return
+	}
+	for w.loops < n {
+		w.loops++
+		w.wg.Add(1)
+		go w.loop()
+	}
+	w.observeParallelism()
+	// wake up the idle loops to exit
+	w.queue.wakeup()
+}
+
+// Parallelism returns the target and the current number of worker loops
+func (w *worker) Parallelism() (int, int) {
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+	return w.parallelism, w.loops
+}
+
+// quitLoop returns true if the loop should exit because of the shrink
+func (w *worker) quitLoop() bool {
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+
+	if w.loops <= w.parallelism {
+		return false
+	}
+	w.loops--
+	w.observeParallelism()
+	return true
+}
+
+func (w *worker) observeParallelism() {
+	if w.parallelismObserver != nil {
+		w.parallelismObserver(w.parallelism, w.loops)
+	}
+}
+
+// Submit submits a single request, requests with higher priority are executed first
+// and requests with the same priority are scheduled fairly across tenants.
+// If the queue is full, the request is rejected with ErrQueueFull or it waits for the
+// capacity until the context is done if queue blocking is enabled
 func (w *worker) Submit(ctx context.Context, req *Request) (<-chan Response, <-chan struct{}) {
 	ch := make(chan Response, 1)
 	started := make(chan struct{})
-	select {
-	case w.workCh <- workRequest{
+	tenant := w.requestTenant(ctx, req)
+	wr := workRequest{
 		Request:  req,
 		Context:  ctx,
 		started:  started,
 		resultCh: ch,
-	}:
-	default:
+		tenant:   tenant,
+		submit:   time.Now(),
+	}
+	reject := func(err error) {
 		close(started)
 		ch <- Response{
 			RequestID: req.RequestID,
-			Error:     fmt.Errorf("worker queue is full"),
+			Error:     err,
 		}
 	}
+	err := w.queue.tryPush(wr)
+	if err == nil {
+		return ch, started
+	}
+	if !w.queueBlocking || errors.Is(err, ErrShuttingDown) {
+		reject(err)
+		return ch, started
+	}
+	go func() {
+		if err := w.queue.push(ctx, wr); err != nil {
+			reject(err)
+		}
+	}()
 	return ch, started
 }
 
-// Execute will execute the request in new goroutine (bypass the parallelism limit)
+// Execute will execute the request in new goroutine (bypass the parallelism limit).
+// It is limited by the execute parallelism instead and rejected with ErrQueueFull
+// if too many requests are waiting
 func (w *worker) Execute(ctx context.Context, req *Request) <-chan Response {
 	ch := make(chan Response, 1)
-	w.wg.Add(1)
+	w.execMu.Lock()
+	defer w.execMu.Unlock()
+	if w.queue.isClosed() {
+		ch <- Response{
+			RequestID: req.RequestID,
+			Error:     ErrShuttingDown,
+		}
+		return ch
+	}
+	w.execWg.Add(1)
 	go func() {
-		defer w.wg.Done()
-		ch <- w.workDoCmd(ctx, req)
+		defer w.execWg.Done()
+		if err := w.execute.acquire(ctx, w.kill); err != nil {
+			ch <- Response{
+				RequestID: req.RequestID,
+				Error:     err,
+			}
+			return
+		}
+		defer w.execute.release()
+		ch <- w.run(ctx, req)
 	}()
 	return ch
 }
 
-// Shutdown waits all worker to finish
+// Drain stops accepting new requests and waits for the running requests, and the
+// queued requests if waitQueued, to finish until the context is done. After that,
+// the running requests are cancelled and the rest are answered with ErrCancelled
+func (w *worker) Drain(ctx context.Context, waitQueued bool) {
+	w.execMu.Lock()
+	w.queue.close()
+	w.execMu.Unlock()
+
+	if !waitQueued {
+		w.cancelQueued()
+	}
+	idle := make(chan struct{})
+	go func() {
+		w.queue.waitIdle(nil)
+		w.execWg.Wait()
+		close(idle)
+	}()
+
+	select {
+	case <-idle:
+		return
+	case <-ctx.Done():
+	}
+	w.cancelQueued()
+	w.killOnce.Do(func() {
+		close(w.kill)
+	})
+	<-idle
+}
+
+// Shutdown waits all worker to finish, the queued requests are answered with ErrCancelled
 func (w *worker) Shutdown() {
 	w.stopOnce.Do(func() {
+		w.execMu.Lock()
+		w.queue.close()
+		w.execMu.Unlock()
+
+		w.loopMu.Lock()
+		w.stopped = true
+		w.loopMu.Unlock()
+
 		close(w.done)
 		w.wg.Wait()
+		w.execWg.Wait()
+		w.cancelQueued()
 	})
 }
 
+// cancelQueued answers the waiting requests with ErrCancelled
+func (w *worker) cancelQueued() {
+	for _, req := range w.queue.drain() {
+		close(req.started)
+		req.resultCh <- Response{
+			RequestID: req.RequestID,
+			Error:     ErrCancelled,
+			WaitTime:  time.Since(req.submit),
+		}
+	}
+}
+
+// run executes the request with the context cancelled either by the request
+// or by the drain timeout
+func (w *worker) run(ctx context.Context, req *Request) Response {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.kill:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rt := w.workDoCmd(ctx, req)
+	select {
+	case <-w.kill:
+		if rt.Error == nil {
+			rt.Error = ErrCancelled
+		}
+	default:
+	}
+	return rt
+}
+
 func (w *worker) loop() {
 	defer w.wg.Done()
 	for {
+		req, ok := w.queue.pop(w.done, w.quitLoop)
+		if !ok {
+			return
+		}
+		close(req.started)
+		waitTime := time.Since(req.submit)
+
 		select {
-		case req, ok := <-w.workCh:
-			if !ok {
-				return
-			}
-			close(req.started)
-
-			select {
-			case <-req.Context.Done():
-				req.resultCh <- Response{
-					RequestID: req.RequestID,
-					Error:     fmt.Errorf("cancelled before execute"),
-				}
-			default:
-				req.resultCh <- w.workDoCmd(req.Context, req.Request)
+		case <-req.Context.Done():
+			req.resultCh <- Response{
+				RequestID: req.RequestID,
+				Error:     fmt.Errorf("cancelled before execute"),
+				WaitTime:  waitTime,
 			}
-
-		case <-w.done:
-			return
+		default:
+			rt := w.run(req.Context, req.Request)
+			rt.WaitTime = waitTime
+			req.resultCh <- rt
 		}
+		w.queue.finish(req)
 	}
 }
 
 func (w *worker) workDoCmd(ctx context.Context, req *Request) Response {
-	var rt Response
-	if len(req.Cmd) == 1 {
-		rt = w.workDoSingle(ctx, req.Cmd[0])
-	} else {
-		rt = w.workDoGroup(ctx, req.Cmd, req.PipeMapping)
+	stages := req.Stages
+	if len(stages) == 0 {
+		stages = []Stage{{
+			Cmd:         req.Cmd,
+			PipeMapping: req.PipeMapping,
+			GroupLimit:  req.GroupLimit,
+			GroupPolicy: req.GroupPolicy,
+			KillOnExit:  req.KillOnExit,
+			Batch:       req.Batch,
+			Interactive: req.Interactive,
+		}}
 	}
+	rt := w.workDoStages(ctx, stages)
 	rt.RequestID = req.RequestID
 	if w.execObserver != nil {
 		w.execObserver(rt)
 	}
+	if w.auditObserver != nil {
+		audited := *req
+		if t, ok := TenantFromContext(ctx); ok {
+			audited.Tenant = t
+		}
+		w.auditObserver(&audited, rt)
+	}
 	return rt
 }
 
 func (w *worker) workDoSingle(ctx context.Context, rc Cmd) (rt Response) {
-	c, err := w.prepareCmd(rc)
+	key, cacheable := w.cacheKey(rc)
+	if cacheable {
+		if res, ok := w.loadCache(key); ok {
+			rt.Results = []Result{res}
+			return
+		}
+	}
+
+	res, err := w.workDoRetry(ctx, rc)
 	if err != nil {
 		rt.Error = err
 		return
 	}
+	if cacheable && ctx.Err() == nil {
+		w.storeCache(key, res)
+	}
+	rt.Results = []Result{res}
+	return
+}
+
+func (w *worker) runSingle(ctx context.Context, rc Cmd) (Result, error) {
+	c, wait, err := w.prepareCmd(rc)
+	if err != nil {
+		return Result{}, err
+	}
 	// prepare environment
 	env, err := w.envPool.Get()
 	if err != nil {
-		return Response{Results: []Result{{
+		return Result{
 			Status: envexec.StatusInternalError,
 			Error:  fmt.Sprintf("failed to get environment %v", err),
-		}}}
+		}, nil
 	}
 	defer w.envPool.Put(env)
 	c.Environment = env
@@ -202,24 +517,30 @@ func (w *worker) workDoSingle(ctx context.Context, rc Cmd) (rt Response) {
 	}
 	result, err := s.Run(ctx)
 	if err != nil {
-		rt.Error = err
-		return
+		return Result{}, err
 	}
-	res := w.convertResult(result, rc)
-	rt.Results = []Result{res}
-	return
+	return w.convertResult(ctx, result, rc, wait), nil
+}
+
+// groupOption defines how the cmd of the group run together
+type groupOption struct {
+	killOnExit []int
+	policy     GroupPolicy
+	limit      GroupLimit
 }
 
-func (w *worker) workDoGroup(ctx context.Context, rc []Cmd, pm []PipeMap) (rt Response) {
+func (w *worker) workDoGroup(ctx context.Context, rc []Cmd, pm []PipeMap, opt groupOption) (rt Response) {
 	var rts []Result
 	cs := make([]*envexec.Cmd, 0, len(rc))
+	waits := make([]*waiter, 0, len(rc))
 	for _, cc := range rc {
-		c, err := w.prepareCmd(cc)
+		c, wait, err := w.prepareCmd(cc)
 		if err != nil {
 			rt.Error = err
 			return
 		}
 		cs = append(cs, c)
+		waits = append(waits, wait)
 	}
 	for i := range cs {
 		env, err := w.envPool.Get()
@@ -239,6 +560,9 @@ func (w *worker) workDoGroup(ctx context.Context, rc []Cmd, pm []PipeMap) (rt Re
 	g := envexec.Group{
 		Cmd:          cs,
 		Pipes:        pm,
+		KillOnExit:   opt.killOnExit,
+		Policy:       opt.policy,
+		Limit:        opt.limit,
 		NewStoreFile: w.fs.New,
 	}
 	results, err := g.Run(ctx)
@@ -248,14 +572,14 @@ func (w *worker) workDoGroup(ctx context.Context, rc []Cmd, pm []PipeMap) (rt Re
 	}
 	rts = make([]Result, 0, len(results))
 	for i, result := range results {
-		res := w.convertResult(result, rc[i])
+		res := w.convertResult(ctx, result, rc[i], waits[i])
 		rts = append(rts, res)
 	}
 	rt.Results = rts
 	return
 }
 
-func (w *worker) convertResult(result envexec.Result, cmd Cmd) (res Result) {
+func (w *worker) convertResult(ctx context.Context, result envexec.Result, cmd Cmd, wait *waiter) (res Result) {
 	res.Status = result.Status
 	res.ExitStatus = result.ExitStatus
 	res.Error = result.Error
@@ -265,20 +589,48 @@ func (w *worker) convertResult(result envexec.Result, cmd Cmd) (res Result) {
 	res.FileError = result.FileError
 	res.Files = make(map[string]*os.File)
 	res.FileIDs = make(map[string]string)
+	res.stageFileIDs = make(map[string]string)
+	res.Killed = result.Killed
+	res.UsageTimeline = wait.timeline(result)
+	res.CopyOutDir = result.CopyOutDir
+	res.OutputSize = result.OutputSize
+	res.GroupLimitExceeded = result.GroupLimitExceeded
 
 	// Fix TLE due to context cancel
 	if res.Status == envexec.StatusTimeLimitExceeded && res.ExitStatus != 0 &&
-		res.Time < cmd.CPULimit && res.RunTime < cmd.ClockLimit {
+		res.GroupLimitExceeded == envexec.GroupLimitNone && res.Time < cmd.CPULimit && res.RunTime < cmd.ClockLimit {
 		res.Status = envexec.StatusSignalled
 	}
+	if wait.idled() && !res.Killed {
+		res.Status = envexec.StatusTimeLimitExceeded
+		res.Error = idleLimitExceeded
+	}
+
+	if cmd.Compare != nil && res.Status == envexec.StatusAccepted {
+		diff, err := w.compareOutput(result, cmd)
+		if err != nil {
+			res.Status = envexec.StatusInternalError
+			res.Error = fmt.Sprintf("compare: %v", err)
+		} else if diff != "" {
+			res.Status = envexec.StatusWrongAnswer
+			res.Diff = diff
+		}
+	}
+	if cmd.Checker != nil && res.Status == envexec.StatusAccepted {
+		w.runChecker(ctx, &res, result, cmd)
+	}
 
 	copyOutCachedSet := make(map[string]bool, len(cmd.CopyOutCached))
 	for _, f := range cmd.CopyOutCached {
 		copyOutCachedSet[f.Name] = true
 	}
+	copyOutStageSet := make(map[string]bool, len(cmd.CopyOutStage))
+	for _, f := range cmd.CopyOutStage {
+		copyOutStageSet[f.Name] = true
+	}
 
 	for name, b := range result.Files {
-		if !copyOutCachedSet[name] {
+		if !copyOutCachedSet[name] && !copyOutStageSet[name] {
 			res.Files[name] = b
 			continue
 		}
@@ -288,23 +640,27 @@ func (w *worker) convertResult(result envexec.Result, cmd Cmd) (res Result) {
 			res.Error = err.Error()
 			return
 		}
-		res.FileIDs[name] = id
+		if copyOutStageSet[name] {
+			res.stageFileIDs[name] = id
+		} else {
+			res.FileIDs[name] = id
+		}
 		b.Close()
 	}
 	return res
 }
 
-func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, error) {
+func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, *waiter, error) {
 	files, pipeFileName, err := w.prepareCmdFiles(rc.Files)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	copyIn, err := w.prepareCopyIn(rc.CopyIn)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	copyOut := make([]envexec.CmdCopyOutFile, 0, len(rc.CopyOut)+len(rc.CopyOutCached))
+	copyOut := make([]envexec.CmdCopyOutFile, 0, len(rc.CopyOut)+len(rc.CopyOutCached)+len(rc.CopyOutStage))
 	for _, fn := range rc.CopyOut {
 		if !pipeFileName[fn.Name] {
 			copyOut = append(copyOut, fn)
@@ -315,11 +671,18 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, error) {
 			copyOut = append(copyOut, fn)
 		}
 	}
+	for _, fn := range rc.CopyOutStage {
+		if !pipeFileName[fn.Name] {
+			copyOut = append(copyOut, fn)
+		}
+	}
 
 	wait := &waiter{
 		tickInterval:  w.timeLimitTickInterval,
 		timeLimit:     time.Duration(rc.CPULimit),
 		realTimeLimit: time.Duration(rc.ClockLimit),
+		idleLimit:     rc.IdleLimit,
+		record:        rc.UsageTimeline,
 	}
 
 	var copyOutDir string
@@ -336,6 +699,10 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, error) {
 	if rc.CopyOutMax > 0 {
 		copyOutMax = envexec.Size(rc.CopyOutMax)
 	}
+	copyOutDirMax := w.copyOutDirLimit
+	if rc.CopyOutDirMax > 0 {
+		copyOutDirMax = envexec.Size(rc.CopyOutDirMax)
+	}
 
 	outputLimit := rc.OutputLimit
 	if outputLimit == 0 {
@@ -365,9 +732,10 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, error) {
 		CopyIn:            copyIn,
 		CopyOut:           copyOut,
 		CopyOutDir:        copyOutDir,
+		CopyOutDirMax:     copyOutDirMax,
 		CopyOutMax:        copyOutMax,
 		Waiter:            wait.Wait,
-	}, nil
+	}, wait, nil
 }
 
 func (w *worker) prepareCopyIn(cf map[string]CmdFile) (map[string]envexec.File, error) {
@@ -380,6 +748,14 @@ func (w *worker) prepareCopyIn(cf map[string]CmdFile) (map[string]envexec.File,
 		if err != nil {
 			return nil, err
 		}
+		if a, ok := pcf.(*envexec.FileArchive); ok {
+			if a.Limit <= 0 {
+				a.Limit = w.archiveLimit
+			}
+			if a.InodeLimit <= 0 {
+				a.InodeLimit = w.archiveInodeLimit
+			}
+		}
 		rt[name] = pcf
 	}
 	return rt, nil
@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExecuteBeforeStart(t *testing.T) {
+	w := New(Config{ExecuteParallelism: 1})
+	w.Drain(context.Background(), false)
+
+	rt := <-w.Execute(context.Background(), &Request{RequestID: "id"})
+	if !errors.Is(rt.Error, ErrShuttingDown) {
+		t.Errorf("expected %v, got %v", ErrShuttingDown, rt.Error)
+	}
+	if rt.RequestID != "id" {
+		t.Errorf("expected request id %q, got %q", "id", rt.RequestID)
+	}
+	w.Shutdown()
+}